@@ -0,0 +1,319 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"os"
+	"regexp"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/skeema/mybase"
+	"github.com/skeema/skeema/fs"
+	"github.com/skeema/tengo"
+)
+
+func init() {
+	summary := "Output the structure of live database schemas as JSON"
+	desc := `Introspects the schemas on the database instances configured for a directory
+and its subdirectories, and writes a JSON representation of their tables,
+columns, indexes, foreign keys, and routines to STDOUT. Unlike the *.sql files
+written by ` + "`" + `skeema pull` + "`" + `, this output is a structured model intended for
+consumption by other tools. The filesystem is not modified.
+
+You may optionally pass an environment name as a CLI option. This will affect
+which section of .skeema config files is used for processing. For example,
+running ` + "`" + `skeema export staging` + "`" + ` will apply config directives from the
+[staging] section of config files, as well as any sectionless directives at the
+top of the file. If no environment name is supplied, the default is
+"production".
+
+An exit code of 0 will be returned if all schemas were exported successfully;
+1 if some dirs or schemas were skipped due to errors; or 2+ if a fatal error
+occurred.`
+
+	cmd := mybase.NewCommand("export", summary, desc, ExportHandler)
+	cmd.AddOption(mybase.StringOption("dir", 'd', ".", "Directory to operate on, instead of the current working directory"))
+	cmd.AddArg("environment", "production", false)
+	CommandSuite.AddSubCommand(cmd)
+}
+
+// The types below define the JSON output format of `skeema export`. They are
+// intentionally separate from tengo's types, so that the output format remains
+// stable even if tengo's internal representation changes. Any changes to these
+// types should be backwards-compatible additions.
+
+// exportedSchema is the JSON representation of one schema.
+type exportedSchema struct {
+	Dir       string            `json:"dir"`
+	Instance  string            `json:"instance"`
+	Name      string            `json:"name"`
+	CharSet   string            `json:"charSet"`
+	Collation string            `json:"collation"`
+	Tables    []exportedTable   `json:"tables"`
+	Routines  []exportedRoutine `json:"routines"`
+}
+
+// exportedTable is the JSON representation of one table.
+type exportedTable struct {
+	Name        string               `json:"name"`
+	Engine      string               `json:"engine"`
+	CharSet     string               `json:"charSet"`
+	Collation   string               `json:"collation"`
+	Comment     string               `json:"comment,omitempty"`
+	Partitioned bool                 `json:"partitioned,omitempty"`
+	Columns     []exportedColumn     `json:"columns"`
+	Indexes     []exportedIndex      `json:"indexes"`
+	ForeignKeys []exportedForeignKey `json:"foreignKeys"`
+}
+
+// exportedColumn is the JSON representation of one column. Default is an SQL
+// expression, meaning string values are quote-wrapped.
+type exportedColumn struct {
+	Name           string `json:"name"`
+	Type           string `json:"type"`
+	Nullable       bool   `json:"nullable"`
+	AutoIncrement  bool   `json:"autoIncrement,omitempty"`
+	Default        string `json:"default,omitempty"`
+	OnUpdate       string `json:"onUpdate,omitempty"`
+	GenerationExpr string `json:"generationExpression,omitempty"`
+	Virtual        bool   `json:"virtual,omitempty"`
+	CharSet        string `json:"charSet,omitempty"`
+	Collation      string `json:"collation,omitempty"`
+	Comment        string `json:"comment,omitempty"`
+}
+
+// exportedIndex is the JSON representation of one index, including the
+// primary key.
+type exportedIndex struct {
+	Name    string              `json:"name"`
+	Primary bool                `json:"primary,omitempty"`
+	Unique  bool                `json:"unique,omitempty"`
+	Type    string              `json:"type"`
+	Parts   []exportedIndexPart `json:"parts"`
+	Comment string              `json:"comment,omitempty"`
+}
+
+// exportedIndexPart is the JSON representation of one column or expression in
+// an index.
+type exportedIndexPart struct {
+	Column       string `json:"column,omitempty"`
+	Expression   string `json:"expression,omitempty"`
+	PrefixLength uint16 `json:"prefixLength,omitempty"`
+	Descending   bool   `json:"descending,omitempty"`
+}
+
+// exportedForeignKey is the JSON representation of one foreign key. The
+// ReferencedSchema is empty if it is the same schema as the table.
+type exportedForeignKey struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedSchema  string   `json:"referencedSchema,omitempty"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+	OnUpdate          string   `json:"onUpdate"`
+	OnDelete          string   `json:"onDelete"`
+}
+
+// exportedRoutine is the JSON representation of one stored procedure or
+// function.
+type exportedRoutine struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Params        string `json:"params"`
+	ReturnType    string `json:"returnType,omitempty"`
+	Definer       string `json:"definer"`
+	Deterministic bool   `json:"deterministic,omitempty"`
+	Comment       string `json:"comment,omitempty"`
+	Body          string `json:"body"`
+}
+
+// ExportHandler is the handler method for `skeema export`
+func ExportHandler(cfg *mybase.Config) error {
+	dir, err := parseBaseDir(cfg)
+	if err != nil {
+		return err
+	}
+
+	schemas := []exportedSchema{}
+	skipCount, err := exportWalker(dir, 5, &schemas)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(schemas); err != nil {
+		return err
+	}
+	if skipCount == 0 {
+		return nil
+	}
+	var plural string
+	if skipCount > 1 {
+		plural = "s"
+	}
+	return NewExitValue(CodePartialError, "Skipped %d operation%s due to error%s", skipCount, plural, plural)
+}
+
+// exportWalker introspects the schemas mapped by dir and appends them to
+// schemas, and recursively calls itself on any subdirs. An error is only
+// returned if something fatal occurs. skipCount reflects the number of
+// non-fatal failed operations that were skipped for dir and its subdirectories.
+func exportWalker(dir *fs.Dir, maxDepth int, schemas *[]exportedSchema) (skipCount int, err error) {
+	if dir.HasSchema() && dir.Config.Changed("host") {
+		return exportSchemaDir(dir, schemas)
+	}
+
+	subdirs, err := dir.Subdirs()
+	if err != nil {
+		log.Errorf("Cannot list subdirs of %s: %s", dir, err)
+		return 1, nil
+	} else if len(subdirs) > 0 && maxDepth <= 0 {
+		log.Warnf("Not walking subdirs of %s: max depth reached", dir)
+		return len(subdirs), nil
+	}
+	for _, sub := range subdirs {
+		if sub.ParseError != nil {
+			log.Warnf("Skipping %s: %s", sub.Path, sub.ParseError)
+			skipCount++
+			continue
+		}
+		subSkipCount, subErr := exportWalker(sub, maxDepth-1, schemas)
+		skipCount += subSkipCount
+		if subErr != nil {
+			return skipCount, subErr
+		}
+	}
+	return skipCount, nil
+}
+
+// exportSchemaDir introspects each schema mapped by dir on its first reachable
+// instance, and appends their JSON representations to schemas. Tables matching
+// ignore-table are omitted.
+func exportSchemaDir(dir *fs.Dir, schemas *[]exportedSchema) (skipCount int, err error) {
+	ignoreTable, err := dir.Config.GetRegexp("ignore-table")
+	if err != nil {
+		return 0, NewExitValue(CodeBadConfig, err.Error())
+	}
+	instance, err := dir.FirstInstance()
+	if err != nil {
+		log.Warnf("Skipping %s: %s", dir, err)
+		return 1, nil
+	}
+	schemaNames, err := dir.SchemaNames(instance)
+	if err != nil {
+		log.Warnf("Skipping %s: Unable to fetch schema names mapped by this dir: %s", dir, err)
+		return 1, nil
+	}
+	for _, name := range schemaNames {
+		schema, err := instance.Schema(name)
+		if err == sql.ErrNoRows {
+			log.Warnf("Skipping %s: schema %s does not exist on %s", dir, name, instance)
+			skipCount++
+			continue
+		} else if err != nil {
+			log.Warnf("Skipping %s: Unable to fetch schema %s from %s: %s", dir, name, instance, err)
+			skipCount++
+			continue
+		}
+		es := exportSchema(schema, ignoreTable)
+		es.Dir = dir.RelPath()
+		es.Instance = instance.String()
+		*schemas = append(*schemas, es)
+	}
+	return skipCount, nil
+}
+
+// exportSchema converts schema to its JSON representation. Tables with names
+// matching ignoreTable are omitted.
+func exportSchema(schema *tengo.Schema, ignoreTable *regexp.Regexp) exportedSchema {
+	es := exportedSchema{
+		Name:      schema.Name,
+		CharSet:   schema.CharSet,
+		Collation: schema.Collation,
+		Tables:    []exportedTable{},
+		Routines:  []exportedRoutine{},
+	}
+	for _, table := range schema.Tables {
+		if ignoreTable == nil || !ignoreTable.MatchString(table.Name) {
+			es.Tables = append(es.Tables, exportTable(table))
+		}
+	}
+	for _, routine := range schema.Routines {
+		es.Routines = append(es.Routines, exportedRoutine{
+			Name:          routine.Name,
+			Type:          string(routine.Type),
+			Params:        routine.ParamString,
+			ReturnType:    routine.ReturnDataType,
+			Definer:       routine.Definer,
+			Deterministic: routine.Deterministic,
+			Comment:       routine.Comment,
+			Body:          routine.Body,
+		})
+	}
+	return es
+}
+
+// exportTable converts table to its JSON representation.
+func exportTable(table *tengo.Table) exportedTable {
+	et := exportedTable{
+		Name:        table.Name,
+		Engine:      table.Engine,
+		CharSet:     table.CharSet,
+		Collation:   table.Collation,
+		Comment:     table.Comment,
+		Partitioned: table.Partitioning != nil,
+		Columns:     make([]exportedColumn, 0, len(table.Columns)),
+		Indexes:     []exportedIndex{},
+		ForeignKeys: make([]exportedForeignKey, 0, len(table.ForeignKeys)),
+	}
+	for _, col := range table.Columns {
+		et.Columns = append(et.Columns, exportedColumn{
+			Name:           col.Name,
+			Type:           col.TypeInDB,
+			Nullable:       col.Nullable,
+			AutoIncrement:  col.AutoIncrement,
+			Default:        col.Default,
+			OnUpdate:       col.OnUpdate,
+			GenerationExpr: col.GenerationExpr,
+			Virtual:        col.Virtual,
+			CharSet:        col.CharSet,
+			Collation:      col.Collation,
+			Comment:        col.Comment,
+		})
+	}
+	indexes := table.SecondaryIndexes
+	if table.PrimaryKey != nil {
+		indexes = append([]*tengo.Index{table.PrimaryKey}, indexes...)
+	}
+	for _, idx := range indexes {
+		ei := exportedIndex{
+			Name:    idx.Name,
+			Primary: idx.PrimaryKey,
+			Unique:  idx.Unique,
+			Type:    idx.Type,
+			Parts:   make([]exportedIndexPart, 0, len(idx.Parts)),
+			Comment: idx.Comment,
+		}
+		for _, part := range idx.Parts {
+			ei.Parts = append(ei.Parts, exportedIndexPart{
+				Column:       part.ColumnName,
+				Expression:   part.Expression,
+				PrefixLength: part.PrefixLength,
+				Descending:   part.Descending,
+			})
+		}
+		et.Indexes = append(et.Indexes, ei)
+	}
+	for _, fk := range table.ForeignKeys {
+		et.ForeignKeys = append(et.ForeignKeys, exportedForeignKey{
+			Name:              fk.Name,
+			Columns:           fk.ColumnNames,
+			ReferencedSchema:  fk.ReferencedSchemaName,
+			ReferencedTable:   fk.ReferencedTableName,
+			ReferencedColumns: fk.ReferencedColumnNames,
+			OnUpdate:          fk.UpdateRule,
+			OnDelete:          fk.DeleteRule,
+		})
+	}
+	return et
+}
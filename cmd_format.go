@@ -31,6 +31,7 @@ An exit code of 0 will be returned if all files were already formatted properly;
 occurred.`
 
 	cmd := mybase.NewCommand("format", summary, desc, FormatHandler)
+	cmd.AddOption(mybase.StringOption("dir", 'd', ".", "Directory to operate on, instead of the current working directory"))
 	cmd.AddOption(mybase.BoolOption("write", 0, true, "Update files to correct format"))
 	cmd.AddArg("environment", "production", false)
 	CommandSuite.AddSubCommand(cmd)
@@ -38,7 +39,7 @@ occurred.`
 
 // FormatHandler is the handler method for `skeema format`
 func FormatHandler(cfg *mybase.Config) error {
-	dir, err := fs.ParseDir(".", cfg)
+	dir, err := parseBaseDir(cfg)
 	if err != nil {
 		return err
 	}
@@ -127,11 +128,11 @@ func formatDir(dir *fs.Dir) error {
 			CountOnly:      !dir.Config.GetBool("write"),
 		}
 		dumpOpts.IgnoreKeys(wsSchema.FailedKeys())
-		reformatCount, err := dumper.DumpSchema(wsSchema.Schema, dir, dumpOpts)
+		dumpResult, err := dumper.DumpSchema(wsSchema.Schema, dir, dumpOpts)
 		if err != nil {
 			return err
 		}
-		totalReformatCount += reformatCount
+		totalReformatCount += dumpResult.Count()
 	}
 	for _, stmt := range dir.IgnoredStatements {
 		log.Debugf("%s: unable to parse statement", stmt.Location())
@@ -11,6 +11,7 @@ import (
 	"github.com/skeema/mybase"
 	"github.com/skeema/skeema/dumper"
 	"github.com/skeema/skeema/fs"
+	"github.com/skeema/skeema/util"
 	"github.com/skeema/tengo"
 )
 
@@ -36,6 +37,7 @@ section of the file.`
 	cmd.AddOption(mybase.StringOption("dir", 'd', "<hostname>", "Subdir name to use for this host's schemas"))
 	cmd.AddOption(mybase.StringOption("schema", 0, "", "Only import the one specified schema; skip creation of subdirs for each schema"))
 	cmd.AddOption(mybase.BoolOption("include-auto-inc", 0, false, "Include starting auto-inc values in table files"))
+	cmd.AddOption(mybase.StringOption("engines", 0, "", "Comma-separated list of storage engines to include; tables using other engines are skipped"))
 	cmd.AddOption(mybase.StringOption("ignore-schema", 0, "", "Ignore schemas that match regex"))
 	cmd.AddOption(mybase.StringOption("ignore-table", 0, "", "Ignore tables that match regex"))
 	cmd.AddArg("environment", "production", false)
@@ -48,7 +50,7 @@ func InitHandler(cfg *mybase.Config) error {
 	// However, if --schema option used, we're only importing one schema and the
 	// schema_name level is skipped.
 	onlySchema := cfg.Get("schema")
-	if isSystemSchema(onlySchema) {
+	if util.IsSystemSchema(onlySchema) {
 		return NewExitValue(CodeBadConfig, "Option --schema may not be set to a system database name")
 	}
 	separateSchemaSubdir := (onlySchema == "")
@@ -94,7 +96,7 @@ func InitHandler(cfg *mybase.Config) error {
 
 	// Iterate over the schemas. For each one, create a dir with .skeema and *.sql files
 	for _, s := range schemas {
-		if err := PopulateSchemaDir(s, hostDir, separateSchemaSubdir); err != nil {
+		if _, err := PopulateSchemaDir(s, hostDir, separateSchemaSubdir); err != nil {
 			return err
 		}
 	}
@@ -102,16 +104,6 @@ func InitHandler(cfg *mybase.Config) error {
 	return nil
 }
 
-func isSystemSchema(name string) bool {
-	systemSchemas := map[string]bool{
-		"mysql":              true,
-		"information_schema": true,
-		"performance_schema": true,
-		"sys":                true,
-	}
-	return systemSchemas[strings.ToLower(name)]
-}
-
 func createHostDir(cfg *mybase.Config) (*fs.Dir, error) {
 	if !cfg.OnCLI("host") {
 		return nil, NewExitValue(CodeBadConfig, "Option --host must be supplied on the command-line")
@@ -202,22 +194,21 @@ func createHostOptionFile(cfg *mybase.Config, hostDir *fs.Dir, inst *tengo.Insta
 // will be created, and a .skeema option file will be created. Otherwise, the
 // *.sql files will be put in parentDir, and it will be the caller's
 // responsibility to ensure its .skeema option file exists and maps to the
-// correct schema name.
-func PopulateSchemaDir(s *tengo.Schema, parentDir *fs.Dir, makeSubdir bool) error {
+// correct schema name. Counts of written statements are returned.
+func PopulateSchemaDir(s *tengo.Schema, parentDir *fs.Dir, makeSubdir bool) (result dumper.Result, err error) {
 	// Ignore any attempt to populate a dir for the temp schema
 	if s.Name == parentDir.Config.Get("temp-schema") {
-		return nil
+		return result, nil
 	}
 
 	if ignoreSchema, err := parentDir.Config.GetRegexp("ignore-schema"); err != nil {
-		return NewExitValue(CodeBadConfig, err.Error())
+		return result, NewExitValue(CodeBadConfig, err.Error())
 	} else if ignoreSchema != nil && ignoreSchema.MatchString(s.Name) {
 		log.Debugf("Skipping schema %s because ignore-schema='%s'", s.Name, ignoreSchema)
-		return nil
+		return result, nil
 	}
 
 	var dir *fs.Dir
-	var err error
 	if makeSubdir {
 		optionFile := mybase.NewFile(path.Join(parentDir.Path, s.Name), ".skeema")
 		optionFile.SetOptionValue("", "schema", s.Name)
@@ -225,7 +216,7 @@ func PopulateSchemaDir(s *tengo.Schema, parentDir *fs.Dir, makeSubdir bool) erro
 		optionFile.SetOptionValue("", "default-collation", s.Collation)
 		dir, err = parentDir.CreateSubdir(s.Name, optionFile)
 		if err != nil {
-			return NewExitValue(CodeCantCreate, "Unable to create subdirectory for schema %s: %s", s.Name, err)
+			return result, NewExitValue(CodeCantCreate, "Unable to create subdirectory for schema %s: %s", s.Name, err)
 		}
 	} else {
 		dir = parentDir
@@ -237,12 +228,17 @@ func PopulateSchemaDir(s *tengo.Schema, parentDir *fs.Dir, makeSubdir bool) erro
 	}
 	dumpOpts.IgnoreTable, err = dir.Config.GetRegexp("ignore-table")
 	if err != nil {
-		return NewExitValue(CodeBadConfig, err.Error())
+		return result, NewExitValue(CodeBadConfig, err.Error())
+	}
+	if engines := dir.Config.GetSlice("engines", ',', true); len(engines) > 0 {
+		dumpOpts.IgnoreKeys(tablesNotUsingEngines(s, engines))
 	}
 
-	if _, err = dumper.DumpSchema(s, dir, dumpOpts); err != nil {
-		return NewExitValue(CodeCantCreate, "Unable to write in %s: %s", dir, err)
+	if result, err = dumper.DumpSchema(s, dir, dumpOpts); err != nil {
+		return result, NewExitValue(CodeCantCreate, "Unable to write in %s: %s", dir, err)
 	}
-	os.Stderr.WriteString("\n")
-	return nil
+	if log.IsLevelEnabled(log.InfoLevel) {
+		os.Stderr.WriteString("\n")
+	}
+	return result, nil
 }
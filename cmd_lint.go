@@ -39,6 +39,7 @@ some files were reformatted; or 2+ if any errors were emitted for any reason.`
 
 	cmd := mybase.NewCommand("lint", summary, desc, LintHandler)
 	linter.AddCommandOptions(cmd)
+	cmd.AddOption(mybase.StringOption("dir", 'd', ".", "Directory to operate on, instead of the current working directory"))
 	cmd.AddOption(mybase.BoolOption("format", 0, true, "Reformat SQL statements to match canonical SHOW CREATE"))
 	cmd.AddArg("environment", "production", false)
 	CommandSuite.AddSubCommand(cmd)
@@ -46,7 +47,7 @@ some files were reformatted; or 2+ if any errors were emitted for any reason.`
 
 // LintHandler is the handler method for `skeema lint`
 func LintHandler(cfg *mybase.Config) error {
-	dir, err := fs.ParseDir(".", cfg)
+	dir, err := parseBaseDir(cfg)
 	if err != nil {
 		return err
 	}
@@ -164,10 +165,11 @@ func lintDir(dir *fs.Dir) *linter.Result {
 				IgnoreTable:    opts.IgnoreTable,
 			}
 			dumpOpts.IgnoreKeys(wsSchema.FailedKeys())
-			result.ReformatCount, err = dumper.DumpSchema(wsSchema.Schema, dir, dumpOpts)
+			dumpResult, err := dumper.DumpSchema(wsSchema.Schema, dir, dumpOpts)
 			if err != nil {
 				result.Fatal(err)
 			}
+			result.ReformatCount = dumpResult.Count()
 		}
 
 		// Check for problems
@@ -4,14 +4,20 @@ import (
 	"database/sql"
 	"fmt"
 	"os"
+	"path"
 	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/skeema/mybase"
 	"github.com/skeema/skeema/dumper"
 	"github.com/skeema/skeema/fs"
+	"github.com/skeema/skeema/util"
 	"github.com/skeema/skeema/workspace"
 	"github.com/skeema/tengo"
+	"golang.org/x/crypto/ssh/terminal"
 )
 
 func init() {
@@ -26,13 +32,30 @@ which section of .skeema config files is used for processing. For example,
 running ` + "`" + `skeema pull staging` + "`" + ` will apply config directives from the
 [staging] section of config files, as well as any sectionless directives at the
 top of the file. If no environment name is supplied, the default is
-"production".`
+"production".
+
+An exit code of 0 will be returned if all dirs were processed successfully; 1
+if some dirs or schemas were skipped due to errors, or if --check-drift found
+drift; or 2+ if a fatal error occurred. When the exit code is 1, the final log
+line reports the number of skipped operations and the number of drifted
+schemas, so that these two situations can be distinguished.`
 
 	cmd := mybase.NewCommand("pull", summary, desc, PullHandler)
 	cmd.AddOption(mybase.BoolOption("include-auto-inc", 0, false, "Include starting auto-inc values in new table files, and update in existing files"))
+	cmd.AddOption(mybase.StringOption("dir", 'd', ".", "Directory to operate on, instead of the current working directory"))
 	cmd.AddOption(mybase.BoolOption("format", 0, true, "Reformat SQL statements to match canonical SHOW CREATE"))
 	cmd.AddOption(mybase.BoolOption("normalize", 0, true, "(deprecated alias for format)").Hidden())
 	cmd.AddOption(mybase.BoolOption("new-schemas", 0, true, "Detect any new schemas and populate new dirs for them"))
+	cmd.AddOption(mybase.BoolOption("dry-run", 0, false, "Output which files would be changed, but don't actually modify them"))
+	cmd.AddOption(mybase.BoolOption("quiet", 0, false, "Only output warnings, errors, and a final summary line"))
+	cmd.AddOption(mybase.BoolOption("force", 0, false, "When a schema no longer exists, delete its entire dir, even if it contains files not managed by Skeema"))
+	cmd.AddOption(mybase.StringOption("engines", 0, "", "Comma-separated list of storage engines to include; tables using other engines are skipped"))
+	cmd.AddOption(mybase.BoolOption("show-diff", 0, false, "Output a diff of each modified CREATE statement to STDOUT"))
+	cmd.AddOption(mybase.StringOption("changes", 0, "create,alter,drop", "Comma-separated list of change types to write to the filesystem: any of create, alter, drop"))
+	cmd.AddOption(mybase.StringOption("table", 0, "", "Only update the file for the table with this name, in each dir's schema"))
+	cmd.AddOption(mybase.BoolOption("timing", 0, false, "After processing all dirs, output the dirs that took the most time"))
+	cmd.AddOption(mybase.BoolOption("check-drift", 0, false, "Compare each schema to others mapped by the same dir, and report any that have drifted"))
+	cmd.AddOption(mybase.BoolOption("preflight", 0, false, "Before modifying any files, confirm all dirs can be parsed and mapped to schemas, and abort if not"))
 	cmd.AddOption(mybase.StringOption("partitioning", 0, "keep", "(slight pull impact of having partitioning=remove in .skeema file for diff/push)").Hidden())
 	cmd.AddArg("environment", "production", false)
 	CommandSuite.AddSubCommand(cmd)
@@ -40,43 +63,159 @@ top of the file. If no environment name is supplied, the default is
 
 // PullHandler is the handler method for `skeema pull`
 func PullHandler(cfg *mybase.Config) error {
-	dir, err := fs.ParseDir(".", cfg)
+	dir, err := parseBaseDir(cfg)
 	if err != nil {
 		return err
 	}
 
-	var skipCount int
-	if skipCount, err = pullWalker(dir, 5); err != nil {
+	// In quiet mode, suppress informational messages other than the summary
+	origLevel := log.GetLevel()
+	if dir.Config.GetBool("quiet") && !dir.Config.GetBool("debug") {
+		log.SetLevel(log.WarnLevel)
+	}
+	if dir.Config.GetBool("preflight") {
+		if problems := pullPreflight(dir, 5); len(problems) > 0 {
+			log.SetLevel(origLevel)
+			for _, problem := range problems {
+				log.Error(problem)
+			}
+			return NewExitValue(CodeFatalError, "Pre-flight check found problems in %d location(s); no files have been modified", len(problems))
+		}
+	}
+	summary := &pullSummary{dryRun: dir.Config.GetBool("dry-run")}
+	skipCount, err := pullWalker(dir, 5, summary)
+	log.SetLevel(origLevel)
+	if err != nil {
 		return err
 	}
-	if skipCount == 0 {
+	summary.skipped = skipCount
+	if !summary.foundOptionFile && skipCount == 0 {
+		if parentFiles, _, err := fs.ParentOptionFiles(dir.Path, cfg); err == nil && len(parentFiles) == 0 {
+			return NewExitValue(CodeBadConfig, "No .skeema files found in %s, its parent dirs, or its subdirs. This does not appear to be a directory managed by Skeema; use `skeema init` to create one first", dir)
+		}
+	}
+	if table := dir.Config.Get("table"); table != "" && summary.tableFound == 0 {
+		return NewExitValue(CodeBadConfig, "Table %s does not exist in any schema processed by this command", table)
+	}
+	if dir.Config.GetBool("timing") {
+		summary.logSlowest(10)
+	}
+	log.Info(summary)
+	var driftMessage string
+	if summary.drifted > 0 {
+		driftMessage = fmt.Sprintf("Found drift in %d schema(s) mapped by the same dir as another schema or host", summary.drifted)
+	}
+	if skipCount == 0 && driftMessage != "" {
+		return NewExitValue(CodeDifferencesFound, "%s", driftMessage)
+	} else if skipCount == 0 {
 		return nil
 	}
 	var plural string
 	if skipCount > 1 {
 		plural = "s"
 	}
-	return NewExitValue(CodePartialError, "Skipped %d operation%s due to error%s", skipCount, plural, plural)
+	message := fmt.Sprintf("Skipped %d operation%s due to error%s", skipCount, plural, plural)
+	if driftMessage != "" {
+		message = fmt.Sprintf("%s. %s", message, driftMessage)
+	}
+	return NewExitValue(CodePartialError, "%s", message)
+}
+
+// pullSummary accumulates counts of changes made across all dirs processed by
+// a single invocation of `skeema pull`. Renamed objects are counted as one
+// addition and one removal, since that is how they appear in a diff.
+type pullSummary struct {
+	objectsAdded    int // CREATE statements added to *.sql files
+	objectsUpdated  int // CREATE statements modified in *.sql files
+	objectsRemoved  int // CREATE statements removed from *.sql files
+	dirsCreated     int // new schema dirs
+	dirsDeleted     int // schema dirs (or their managed files) removed
+	skipped         int // operations skipped due to errors
+	dryRun          bool
+	tableFound      int  // schemas containing the table requested via --table
+	drifted         int  // schemas found to differ from their dir's representative schema or instance, with --check-drift
+	foundOptionFile bool // true if any processed dir had a .skeema file
+	timings         []dirTiming
+}
+
+// dirTiming tracks how long it took to process a single dir.
+type dirTiming struct {
+	dir     *fs.Dir
+	elapsed time.Duration
+}
+
+// addTiming records the time elapsed since start for processing dir. It is
+// intended for use in a defer.
+func (sum *pullSummary) addTiming(dir *fs.Dir, start time.Time) {
+	sum.timings = append(sum.timings, dirTiming{dir: dir, elapsed: time.Since(start)})
+}
+
+// logSlowest logs the limit dirs that took the most time to process, slowest
+// first.
+func (sum *pullSummary) logSlowest(limit int) {
+	sort.SliceStable(sum.timings, func(i, j int) bool {
+		return sum.timings[i].elapsed > sum.timings[j].elapsed
+	})
+	if len(sum.timings) < limit {
+		limit = len(sum.timings)
+	}
+	if limit == 0 {
+		return
+	}
+	log.Infof("Slowest %d dir(s):", limit)
+	for _, timing := range sum.timings[:limit] {
+		log.Infof("  %s: %s", timing.dir, timing.elapsed.Round(time.Millisecond))
+	}
+}
+
+// addDumpResult adds the counts from a dumper.Result into the summary.
+func (sum *pullSummary) addDumpResult(result dumper.Result) {
+	sum.objectsAdded += result.Added
+	sum.objectsUpdated += result.Updated
+	sum.objectsRemoved += result.Removed
+}
+
+func (sum *pullSummary) String() string {
+	prefix := "Pull complete"
+	if sum.dryRun {
+		prefix = "Dry run complete, no files modified"
+	}
+	result := fmt.Sprintf("%s: objects added/updated/removed %d/%d/%d; schema dirs created/deleted %d/%d",
+		prefix, sum.objectsAdded, sum.objectsUpdated, sum.objectsRemoved, sum.dirsCreated, sum.dirsDeleted)
+	if sum.drifted > 0 {
+		result = fmt.Sprintf("%s; %d drifted", result, sum.drifted)
+	}
+	if sum.skipped > 0 {
+		result = fmt.Sprintf("%s; %d skipped due to errors", result, sum.skipped)
+	}
+	return result
 }
 
 // pullWalker processes dir, and recursively calls itself on any subdirs. An
 // error is only returned if something fatal occurs. skipCount reflects the
 // number of non-fatal failed operations that were skipped for dir and its
-// subdirectories.
-func pullWalker(dir *fs.Dir, maxDepth int) (skipCount int, err error) {
+// subdirectories. Counts of changes made are accumulated in summary.
+func pullWalker(dir *fs.Dir, maxDepth int, summary *pullSummary) (skipCount int, err error) {
+	if dir.OptionFile != nil {
+		summary.foundOptionFile = true
+	}
 	var instance *tengo.Instance
+	var driftInstances []*tengo.Instance
 	if dir.Config.Changed("host") {
 		instance, err = dir.FirstInstance()
 		if err != nil {
 			log.Warnf("Skipping %s: %s", dir, err)
 			return 1, nil
 		}
+		if dir.Config.GetBool("check-drift") {
+			driftInstances = reachableOtherInstances(dir, instance)
+		}
 	}
 
 	// "flat" dir defining both host and schema
 	if instance != nil && dir.HasSchema() {
 		updateFlavor(dir, instance)
-		_, err = pullSchemaDir(dir, instance)
+		_, err = pullSchemaDir(dir, instance, driftInstances, summary)
 		return skipCount, err
 	}
 
@@ -89,7 +228,11 @@ func pullWalker(dir *fs.Dir, maxDepth int) (skipCount int, err error) {
 		return skipCount + len(subdirs), nil
 	}
 
-	wantNewSchemas := dir.Config.GetBool("new-schemas")
+	allowedChanges, err := pullChangeTypes(dir.Config)
+	if err != nil {
+		return skipCount, err
+	}
+	wantNewSchemas := dir.Config.GetBool("new-schemas") && dir.Config.Get("table") == "" && allowedChanges["create"]
 	allSchemaNames := []string{}
 	for _, sub := range subdirs {
 		if sub.ParseError != nil {
@@ -101,7 +244,7 @@ func pullWalker(dir *fs.Dir, maxDepth int) (skipCount int, err error) {
 
 		// If dir does not define host, simply recurse into subdirs.
 		if instance == nil {
-			subSkipCount, subErr := pullWalker(sub, maxDepth-1)
+			subSkipCount, subErr := pullWalker(sub, maxDepth-1, summary)
 			skipCount += subSkipCount
 			if subErr != nil {
 				return skipCount, subErr
@@ -112,7 +255,7 @@ func pullWalker(dir *fs.Dir, maxDepth int) (skipCount int, err error) {
 		// Otherwise, dir defines host but not schema. Treat subdirs as schema dirs,
 		// and use the combined list of handled schemas to figure out whether any
 		// new schema dirs need to be created (if requested).
-		subSchemaNames, subErr := pullSchemaDir(sub, instance)
+		subSchemaNames, subErr := pullSchemaDir(sub, instance, driftInstances, summary)
 		if subErr != nil {
 			return skipCount, subErr
 		}
@@ -122,18 +265,70 @@ func pullWalker(dir *fs.Dir, maxDepth int) (skipCount int, err error) {
 	if instance != nil {
 		updateFlavor(dir, instance)
 		if wantNewSchemas {
-			err = findNewSchemas(dir, instance, allSchemaNames)
+			err = findNewSchemas(dir, instance, allSchemaNames, summary)
 		}
 	}
 	return skipCount, err
 }
 
+// pullPreflight walks dir and its subdirs in the same manner as pullWalker,
+// but without modifying anything. It confirms that each dir can be parsed, that
+// each host can be reached, and that each schema dir's schema names can be
+// resolved. A description of each problem found is returned.
+func pullPreflight(dir *fs.Dir, maxDepth int) (problems []string) {
+	var instance *tengo.Instance
+	if dir.Config.Changed("host") {
+		var err error
+		if instance, err = dir.FirstInstance(); err != nil {
+			return []string{fmt.Sprintf("%s: %s", dir, err)}
+		}
+	}
+	if instance != nil && dir.HasSchema() {
+		return preflightSchemaDir(dir, instance)
+	}
+
+	subdirs, err := dir.Subdirs()
+	if err != nil {
+		return []string{fmt.Sprintf("%s: Cannot list subdirs: %s", dir, err)}
+	} else if len(subdirs) > 0 && maxDepth <= 0 {
+		return []string{fmt.Sprintf("%s: Not walking subdirs: max depth reached", dir)}
+	}
+	for _, sub := range subdirs {
+		if sub.ParseError != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", sub, sub.ParseError))
+		} else if instance == nil {
+			problems = append(problems, pullPreflight(sub, maxDepth-1)...)
+		} else {
+			problems = append(problems, preflightSchemaDir(sub, instance)...)
+		}
+	}
+	return problems
+}
+
+// preflightSchemaDir confirms that the schema names mapped by dir can be
+// resolved on instance.
+func preflightSchemaDir(dir *fs.Dir, instance *tengo.Instance) (problems []string) {
+	for _, logicalSchema := range dir.LogicalSchemas {
+		if logicalSchema.Name != "" {
+			continue // see pullLogicalSchema; these are skipped
+		}
+		if _, err := dir.SchemaNames(instance); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: Unable to fetch schema names mapped by this dir: %s", dir, err))
+		}
+	}
+	return problems
+}
+
 // pullSchemaDir updates all logical schemas in dir to reflect the actual
-// definitions found in instance. A slice of handled schema names is returned,
-// along with any error encountered.
-func pullSchemaDir(dir *fs.Dir, instance *tengo.Instance) (schemaNames []string, err error) {
+// definitions found in instance. If check-drift is enabled, the schemas are
+// also compared to their counterparts on driftInstances. A slice of handled
+// schema names is returned, along with any error encountered.
+func pullSchemaDir(dir *fs.Dir, instance *tengo.Instance, driftInstances []*tengo.Instance, summary *pullSummary) (schemaNames []string, err error) {
+	if dir.OptionFile != nil {
+		summary.foundOptionFile = true
+	}
 	for _, logicalSchema := range dir.LogicalSchemas {
-		names, err := pullLogicalSchema(dir, instance, logicalSchema)
+		names, err := pullLogicalSchema(dir, instance, logicalSchema, driftInstances, summary)
 		if err != nil {
 			return nil, err
 		}
@@ -145,7 +340,8 @@ func pullSchemaDir(dir *fs.Dir, instance *tengo.Instance) (schemaNames []string,
 // pullLogicalSchema performs appropriate pull logic on a dir that maps to one or
 // more schemas. A slice of handled schema names is returned, along with any
 // error encountered.
-func pullLogicalSchema(dir *fs.Dir, instance *tengo.Instance, logicalSchema *fs.LogicalSchema) (schemaNames []string, err error) {
+func pullLogicalSchema(dir *fs.Dir, instance *tengo.Instance, logicalSchema *fs.LogicalSchema, driftInstances []*tengo.Instance, summary *pullSummary) (schemaNames []string, err error) {
+	defer summary.addTiming(dir, time.Now())
 	if logicalSchema.Name != "" {
 		// TODO: support pull for case where multiple explicitly-named schemas per
 		// dir. For example, ability to convert a multi-schema single-file mysqldump
@@ -160,29 +356,99 @@ func pullLogicalSchema(dir *fs.Dir, instance *tengo.Instance, logicalSchema *fs.
 		log.Warnf("Ignoring directory %s -- did not map to any schema names for environment \"%s\"\n", dir, dir.Config.Get("environment"))
 		return
 	}
+	allowedChanges, err := pullChangeTypes(dir.Config)
+	if err != nil {
+		return nil, err
+	}
 	instSchema, err := instance.Schema(schemaNames[0])
-	if err == sql.ErrNoRows {
-		log.Infof("Deleted directory %s -- schema %s no longer exists\n", dir, schemaNames[0])
-		return nil, dir.Delete()
+	if err == sql.ErrNoRows && dir.Config.Get("table") != "" {
+		log.Warnf("Skipping %s -- schema %s no longer exists, but --table is in use", dir, schemaNames[0])
+		return nil, nil
+	} else if err == sql.ErrNoRows && !allowedChanges["drop"] {
+		log.Warnf("Skipping %s -- schema %s no longer exists, but drop is not included in --changes", dir, schemaNames[0])
+		return nil, nil
+	} else if err == sql.ErrNoRows {
+		summary.dirsDeleted++
+		if dir.Config.GetBool("dry-run") {
+			log.Infof("Directory %s would be deleted -- schema %s no longer exists\n", dir, schemaNames[0])
+			return nil, nil
+		}
+		if dir.Config.GetBool("force") {
+			log.Infof("Deleted directory %s -- schema %s no longer exists\n", dir, schemaNames[0])
+			return nil, dir.Delete()
+		}
+		remaining, err := dir.DeleteManagedFiles()
+		if err != nil {
+			return nil, fmt.Errorf("%s: Unable to delete files for schema %s: %s", dir, schemaNames[0], err)
+		} else if len(remaining) > 0 {
+			log.Warnf("Deleted *.sql files and .skeema file from %s -- schema %s no longer exists. Directory was kept since it also contains: %s\n", dir, schemaNames[0], strings.Join(remaining, ", "))
+		} else {
+			log.Infof("Deleted directory %s -- schema %s no longer exists\n", dir, schemaNames[0])
+		}
+		return nil, nil
 	} else if err != nil {
 		return nil, fmt.Errorf("%s: Unable to fetch schema %s from %s: %s", dir, schemaNames[0], instance, err)
 	}
 
-	log.Infof("Updating %s to reflect %s %s", dir, instance, instSchema.Name)
+	// When --table is in use, skip schemas lacking that table entirely. Otherwise,
+	// only that table's file is updated, and schema-level changes are skipped.
+	table := dir.Config.Get("table")
+	if table != "" {
+		if !instSchema.HasTable(table) {
+			log.Debugf("Skipping %s -- table %s does not exist in %s %s", dir, table, instance, instSchema.Name)
+			return schemaNames, nil
+		}
+		summary.tableFound++
+		log.Infof("Updating %s to reflect %s %s.%s", dir, instance, instSchema.Name, table)
+	} else {
+		log.Infof("Updating %s to reflect %s %s", dir, instance, instSchema.Name)
+	}
+
+	// If the dir maps to multiple schemas, the first one is treated as the
+	// representative definition. If requested, report any others that have
+	// drifted from it.
+	if len(schemaNames) > 1 && dir.Config.GetBool("check-drift") {
+		drift, err := reportSchemaDrift(dir, instance, instSchema, schemaNames[1:])
+		if err != nil {
+			return nil, err
+		}
+		summary.drifted += len(drift)
+	}
+
+	// Similarly, if the dir maps to multiple instances, report any other
+	// reachable instance whose copy of the schema has drifted.
+	if len(driftInstances) > 0 && dir.Config.GetBool("check-drift") {
+		drifted, err := reportInstanceDrift(dir, instance, driftInstances, instSchema)
+		if err != nil {
+			return nil, err
+		}
+		summary.drifted += len(drifted)
+	}
 
 	// Handle changes in schema's default character set and/or collation by
-	// persisting changes to the dir's option file.
-	if dir.Config.Get("default-character-set") != instSchema.CharSet || dir.Config.Get("default-collation") != instSchema.Collation {
-		dir.OptionFile.SetOptionValue("", "default-character-set", instSchema.CharSet)
-		dir.OptionFile.SetOptionValue("", "default-collation", instSchema.Collation)
-		if err := dir.OptionFile.Write(true); err != nil {
-			return nil, fmt.Errorf("Unable to update character set and collation for %s: %s", dir.OptionFile.Path(), err)
+	// persisting changes to the dir's option file. This is treated as an alter,
+	// for purposes of the changes option.
+	if table == "" && allowedChanges["alter"] && (dir.Config.Get("default-character-set") != instSchema.CharSet || dir.Config.Get("default-collation") != instSchema.Collation) {
+		if dir.Config.GetBool("dry-run") {
+			log.Infof("File %s would be updated -- schema-level default-character-set and default-collation changed", dir.OptionFile.Path())
+		} else {
+			dir.OptionFile.SetOptionValue("", "default-character-set", instSchema.CharSet)
+			dir.OptionFile.SetOptionValue("", "default-collation", instSchema.Collation)
+			if err := dir.OptionFile.Write(true); err != nil {
+				return nil, fmt.Errorf("Unable to update character set and collation for %s: %s", dir.OptionFile.Path(), err)
+			}
+			log.Infof("Wrote %s -- updated schema-level default-character-set and default-collation", dir.OptionFile.Path())
 		}
-		log.Infof("Wrote %s -- updated schema-level default-character-set and default-collation", dir.OptionFile.Path())
 	}
 
+	warnFileNameMismatches(logicalSchema)
+
 	dumpOpts := dumper.Options{
-		IncludeAutoInc: dir.Config.GetBool("include-auto-inc"),
+		IncludeAutoInc:  dir.Config.GetBool("include-auto-inc"),
+		CountOnly:       dir.Config.GetBool("dry-run"),
+		DescribeChanges: true,
+		ShowDiff:        dir.Config.GetBool("show-diff"),
+		ColorDiff:       terminal.IsTerminal(int(os.Stdout.Fd())),
 	}
 	if dumpOpts.IgnoreTable, err = dir.Config.GetRegexp("ignore-table"); err != nil {
 		return nil, NewExitValue(CodeBadConfig, err.Error())
@@ -190,6 +456,17 @@ func pullLogicalSchema(dir *fs.Dir, instance *tengo.Instance, logicalSchema *fs.
 	if partitioning, _ := dir.Config.GetEnum("partitioning", "keep", "remove", "modify"); partitioning == "remove" {
 		dumpOpts.RetainPartitioning = true
 	}
+	if table != "" {
+		// IgnoreKeys is used rather than OnlyKeys, since OnlyKeys is additive and
+		// would be widened by the --skip-format logic below
+		dumpOpts.IgnoreKeys(otherObjectKeys(instSchema, logicalSchema, tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: table}))
+	}
+	if engines := dir.Config.GetSlice("engines", ',', true); len(engines) > 0 {
+		dumpOpts.IgnoreKeys(tablesNotUsingEngines(instSchema, engines))
+	}
+	if len(allowedChanges) < len(pullChangeTypeNames) {
+		dumpOpts.IgnoreKeys(keysForDisallowedChanges(instSchema, logicalSchema, allowedChanges))
+	}
 
 	// When --skip-format is in use, we only want to update objects that have
 	// actual functional modifications, NOT just cosmetic/formatting differences.
@@ -208,9 +485,120 @@ func pullLogicalSchema(dir *fs.Dir, instance *tengo.Instance, logicalSchema *fs.
 		dumpOpts.OnlyKeys(inDiff)
 	}
 
-	_, err = dumper.DumpSchema(instSchema, dir, dumpOpts)
-	os.Stderr.WriteString("\n")
-	return
+	result, err := dumper.DumpSchema(instSchema, dir, dumpOpts)
+	summary.addDumpResult(result)
+	if result.Diff != "" {
+		fmt.Print(result.Diff)
+	}
+	if log.IsLevelEnabled(log.InfoLevel) {
+		os.Stderr.WriteString("\n")
+	}
+	return schemaNames, err
+}
+
+// warnFileNameMismatches logs a warning for each file in logicalSchema that
+// creates exactly one table, if the file's name doesn't match the table's name.
+func warnFileNameMismatches(logicalSchema *fs.LogicalSchema) {
+	seen := make(map[*fs.TokenizedSQLFile]bool)
+	var files []*fs.TokenizedSQLFile
+	for _, stmt := range logicalSchema.Creates {
+		if !seen[stmt.FromFile] {
+			seen[stmt.FromFile] = true
+			files = append(files, stmt.FromFile)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path() < files[j].Path()
+	})
+	for _, file := range files {
+		if !file.FileNameMatchesTable() {
+			log.Warnf("%s: file name does not match name of table `%s` created in the file; consider renaming the file to %s", file, file.TableName(), fs.PathForObject("", file.TableName()))
+		}
+	}
+}
+
+// otherObjectKeys returns the keys of all objects in schema or logicalSchema,
+// other than keep.
+func otherObjectKeys(schema *tengo.Schema, logicalSchema *fs.LogicalSchema, keep tengo.ObjectKey) (keys []tengo.ObjectKey) {
+	schemaObjects := schema.ObjectDefinitions()
+	for key := range schemaObjects {
+		if key != keep {
+			keys = append(keys, key)
+		}
+	}
+	for key := range logicalSchema.Creates {
+		if _, inSchema := schemaObjects[key]; !inSchema && key != keep {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// pullChangeTypeNames lists the valid values for the pull "changes" option.
+var pullChangeTypeNames = []string{"create", "alter", "drop"}
+
+// pullChangeTypes returns the set of change types that pull is permitted to
+// write to the filesystem, based on the "changes" option. An error is returned
+// if the option contains an unrecognized change type.
+func pullChangeTypes(config *mybase.Config) (map[string]bool, error) {
+	allowed := make(map[string]bool, len(pullChangeTypeNames))
+	for _, value := range config.GetSlice("changes", ',', true) {
+		value = strings.ToLower(value)
+		var valid bool
+		for _, name := range pullChangeTypeNames {
+			if value == name {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, NewExitValue(CodeBadConfig, "Option changes can only contain these values: %s. Invalid value %q supplied", strings.Join(pullChangeTypeNames, ", "), value)
+		}
+		allowed[value] = true
+	}
+	return allowed, nil
+}
+
+// keysForDisallowedChanges returns the keys of objects whose change type is not
+// in allowed. Objects only in schema are creates, objects in both are alters,
+// and objects only in logicalSchema are drops. A renamed object is treated as
+// a drop of its old name and a create of its new name.
+func keysForDisallowedChanges(schema *tengo.Schema, logicalSchema *fs.LogicalSchema, allowed map[string]bool) (keys []tengo.ObjectKey) {
+	schemaObjects := schema.ObjectDefinitions()
+	for key := range schemaObjects {
+		_, inFS := logicalSchema.Creates[key]
+		if (inFS && !allowed["alter"]) || (!inFS && !allowed["create"]) {
+			keys = append(keys, key)
+		}
+	}
+	if !allowed["drop"] {
+		for key := range logicalSchema.Creates {
+			if _, inSchema := schemaObjects[key]; !inSchema {
+				keys = append(keys, key)
+			}
+		}
+	}
+	return keys
+}
+
+// tablesNotUsingEngines returns the keys of tables in schema whose storage
+// engine is not in the supplied list. Engine names are compared
+// case-insensitively.
+func tablesNotUsingEngines(schema *tengo.Schema, engines []string) (keys []tengo.ObjectKey) {
+	for _, table := range schema.Tables {
+		var found bool
+		for _, engine := range engines {
+			if strings.EqualFold(table.Engine, engine) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Debugf("Skipping table %s because its storage engine %s is not in engines list", table.Name, table.Engine)
+			keys = append(keys, tengo.ObjectKey{Type: tengo.ObjectTypeTable, Name: table.Name})
+		}
+	}
+	return keys
 }
 
 func statementModifiersForPull(config *mybase.Config, instance *tengo.Instance, ignoreTable *regexp.Regexp) tengo.StatementModifiers {
@@ -279,16 +667,128 @@ func objectsInDiff(logicalSchema *fs.LogicalSchema, instSchema *tengo.Schema, op
 	return inDiff, nil
 }
 
+// reportSchemaDrift compares each of otherNames on instance to instSchema,
+// logging an error for any schema whose structure differs. The returned map is
+// keyed by name of each drifted schema, with values listing the keys of the
+// differing objects. An error is only returned if a schema cannot be
+// introspected or the ignore-table option is invalid.
+func reportSchemaDrift(dir *fs.Dir, instance *tengo.Instance, instSchema *tengo.Schema, otherNames []string) (drift map[string][]string, err error) {
+	mods, err := statementModifiersForDrift(dir, instance)
+	if err != nil {
+		return nil, err
+	}
+	drift = make(map[string][]string)
+	for _, name := range otherNames {
+		otherSchema, err := instance.Schema(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: Unable to fetch schema %s from %s: %s", dir, name, instance, err)
+		}
+		if driftKeys := schemaDriftKeys(instSchema, otherSchema, mods); len(driftKeys) > 0 {
+			log.Errorf("Schema %s on %s has drifted from %s, which %s is based on: differences found in %s", name, instance, instSchema.Name, dir, strings.Join(driftKeys, ", "))
+			drift[name] = driftKeys
+		}
+	}
+	return drift, nil
+}
+
+// reachableOtherInstances returns the instances mapped by dir other than
+// instance, omitting any that cannot be reached. A warning is logged for each
+// unreachable instance. This is only called once per host-defining dir, so
+// that an unreachable instance isn't re-checked for every schema dir.
+func reachableOtherInstances(dir *fs.Dir, instance *tengo.Instance) (others []*tengo.Instance) {
+	instances, err := dir.Instances()
+	if err != nil {
+		return nil
+	}
+	for _, other := range instances {
+		if other == instance {
+			continue
+		}
+		if ok, connErr := other.CanConnect(); !ok {
+			log.Warnf("Unable to connect to %s for %s, so drift cannot be checked there: %s", other, dir, connErr)
+			continue
+		}
+		others = append(others, other)
+	}
+	return others
+}
+
+// reportInstanceDrift compares instSchema to the same-named schema on each of
+// others, logging an error for any instance where the schema's structure
+// differs, or where the schema does not exist. The returned slice contains the
+// string representation of each such instance. An error is only returned if a
+// schema cannot be introspected or the ignore-table option is invalid.
+func reportInstanceDrift(dir *fs.Dir, instance *tengo.Instance, others []*tengo.Instance, instSchema *tengo.Schema) (drifted []string, err error) {
+	mods, err := statementModifiersForDrift(dir, instance)
+	if err != nil {
+		return nil, err
+	}
+	for _, other := range others {
+		otherSchema, err := other.Schema(instSchema.Name)
+		if err == sql.ErrNoRows {
+			log.Errorf("Schema %s exists on %s but not on %s, which is also mapped by %s", instSchema.Name, instance, other, dir)
+			drifted = append(drifted, other.String())
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: Unable to fetch schema %s from %s: %s", dir, instSchema.Name, other, err)
+		}
+		if driftKeys := schemaDriftKeys(instSchema, otherSchema, mods); len(driftKeys) > 0 {
+			log.Errorf("Schema %s on %s has drifted from %s, which %s is based on: differences found in %s", instSchema.Name, other, instance, dir, strings.Join(driftKeys, ", "))
+			drifted = append(drifted, other.String())
+		}
+	}
+	return drifted, nil
+}
+
+// statementModifiersForDrift returns modifiers for detecting structural drift
+// between two live schemas. Differences in next auto-increment values, or in
+// tables matching ignore-table, are not considered drift.
+func statementModifiersForDrift(dir *fs.Dir, instance *tengo.Instance) (mods tengo.StatementModifiers, err error) {
+	mods = tengo.StatementModifiers{
+		AllowUnsafe: true,
+		NextAutoInc: tengo.NextAutoIncIgnore,
+		Flavor:      instance.Flavor(),
+	}
+	if mods.IgnoreTable, err = dir.Config.GetRegexp("ignore-table"); err != nil {
+		return mods, NewExitValue(CodeBadConfig, err.Error())
+	}
+	return mods, nil
+}
+
+// schemaDriftKeys returns string representations of the keys of objects that
+// differ between from and to, as filtered by mods.
+func schemaDriftKeys(from, to *tengo.Schema, mods tengo.StatementModifiers) (driftKeys []string) {
+	for _, od := range tengo.NewSchemaDiff(from, to).ObjectDiffs() {
+		// Unsupported diffs still indicate a difference, so any error counts too
+		if stmt, stmtErr := od.Statement(mods); stmt != "" || stmtErr != nil {
+			driftKeys = append(driftKeys, od.ObjectKey().String())
+		}
+	}
+	return driftKeys
+}
+
 // updateFlavor updates the dir's .skeema option file if the instance's current
 // flavor does not match what's in the file. However, it leaves the value in the
 // file alone if it's specified and we're unable to detect the instance's
 // vendor, as this gives operators the ability to manually override an
-// undetectable flavor.
+// undetectable flavor. The file is also left alone if the changes option does
+// not include "alter".
 func updateFlavor(dir *fs.Dir, instance *tengo.Instance) {
 	instFlavor := instance.Flavor()
 	if !instFlavor.Known() || instFlavor.Family().String() == dir.Config.Get("flavor") {
 		return
 	}
+	// An invalid changes value is reported elsewhere, so it is just a no-op here
+	if allowedChanges, err := pullChangeTypes(dir.Config); err != nil {
+		return
+	} else if !allowedChanges["alter"] {
+		log.Debugf("Skipping flavor update for %s -- alter is not included in --changes", dir.OptionFile.Path())
+		return
+	}
+	if dir.Config.GetBool("dry-run") {
+		log.Infof("File %s would be updated -- flavor changed to %s", dir.OptionFile.Path(), instFlavor.Family().String())
+		return
+	}
 	dir.OptionFile.SetOptionValue(dir.Config.Get("environment"), "flavor", instFlavor.Family().String())
 	if err := dir.OptionFile.Write(true); err != nil {
 		log.Warnf("Unable to update flavor in %s: %s", dir.OptionFile.Path(), err)
@@ -297,7 +797,7 @@ func updateFlavor(dir *fs.Dir, instance *tengo.Instance) {
 	}
 }
 
-func findNewSchemas(dir *fs.Dir, instance *tengo.Instance, seenNames []string) error {
+func findNewSchemas(dir *fs.Dir, instance *tengo.Instance, seenNames []string, summary *pullSummary) error {
 	subdirHasSchema := make(map[string]bool)
 	for _, name := range seenNames {
 		subdirHasSchema[name] = true
@@ -307,18 +807,32 @@ func findNewSchemas(dir *fs.Dir, instance *tengo.Instance, seenNames []string) e
 	if err != nil {
 		return err
 	}
+	ignoreSchema, err := dir.Config.GetRegexp("ignore-schema")
+	if err != nil {
+		return NewExitValue(CodeBadConfig, err.Error())
+	}
 	for _, name := range schemaNames {
-		// If no existing subdir maps to the schema, we need to create and populate new dir
-		if !subdirHasSchema[name] {
-			s, err := instance.Schema(name)
-			if err != nil {
-				return err
-			}
-			// use same logic from init command
-			if err := PopulateSchemaDir(s, dir, true); err != nil {
-				return err
-			}
+		// If no existing subdir maps to the schema, we need to create and populate
+		// new dir -- unless it's a schema that PopulateSchemaDir would skip anyway
+		if subdirHasSchema[name] || util.IsSystemSchema(name) || name == dir.Config.Get("temp-schema") || (ignoreSchema != nil && ignoreSchema.MatchString(name)) {
+			continue
+		}
+		if dir.Config.GetBool("dry-run") {
+			log.Infof("Directory %s would be created for new schema %s", path.Join(dir.Path, name), name)
+			summary.dirsCreated++
+			continue
+		}
+		s, err := instance.Schema(name)
+		if err != nil {
+			return err
+		}
+		// use same logic from init command
+		result, err := PopulateSchemaDir(s, dir, true)
+		if err != nil {
+			return err
 		}
+		summary.dirsCreated++
+		summary.addDumpResult(result)
 	}
 
 	return nil
@@ -6,7 +6,6 @@ import (
 
 	"github.com/skeema/mybase"
 	"github.com/skeema/skeema/applier"
-	"github.com/skeema/skeema/fs"
 	"github.com/skeema/skeema/linter"
 	"golang.org/x/sync/errgroup"
 )
@@ -26,6 +25,7 @@ top of the file. If no environment name is supplied, the default is
 "production".`
 
 	cmd := mybase.NewCommand("push", summary, desc, PushHandler)
+	cmd.AddOption(mybase.StringOption("dir", 'd', ".", "Directory to operate on, instead of the current working directory"))
 	cmd.AddOption(mybase.BoolOption("verify", 0, true, "Test all generated ALTER statements on temp schema to verify correctness"))
 	cmd.AddOption(mybase.BoolOption("allow-unsafe", 0, false, "Permit running ALTER or DROP operations that are potentially destructive"))
 	cmd.AddOption(mybase.BoolOption("dry-run", 0, false, "Output DDL but don't run it; equivalent to `skeema diff`"))
@@ -52,7 +52,7 @@ top of the file. If no environment name is supplied, the default is
 
 // PushHandler is the handler method for `skeema push`
 func PushHandler(cfg *mybase.Config) error {
-	dir, err := fs.ParseDir(".", cfg)
+	dir, err := parseBaseDir(cfg)
 	if err != nil {
 		return err
 	}
@@ -11,6 +11,9 @@ type Options struct {
 	IncludeAutoInc     bool                     // if false, strip AUTO_INCREMENT clauses from CREATE TABLE
 	RetainPartitioning bool                     // if true, and fs stmt has partitioning, but db doesn't, retain fs partitioning clause
 	CountOnly          bool                     // if true, skip writing files, just report count of rewrites
+	DescribeChanges    bool                     // if true, and CountOnly is true, log each would-be addition, update, or deletion instead of just which files need formatting changes
+	ShowDiff           bool                     // if true, include a unified diff in Result.Diff for each updated statement
+	ColorDiff          bool                     // if true, and ShowDiff is true, colorize the diff output
 	IgnoreTable        *regexp.Regexp           // skip tables with names matching this regex
 	skipKeys           map[tengo.ObjectKey]bool // skip objects with true values
 	onlyKeys           map[tengo.ObjectKey]bool // if map is non-nil, only format objects with true values
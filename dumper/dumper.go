@@ -6,7 +6,10 @@ package dumper
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/pmezard/go-difflib/difflib"
 	log "github.com/sirupsen/logrus"
 	"github.com/skeema/skeema/fs"
 	"github.com/skeema/tengo"
@@ -19,24 +22,88 @@ type statement struct {
 	fsStatement      *fs.Statement
 }
 
+// Result tracks counts of statements modified by DumpSchema.
+type Result struct {
+	Added   int    // statements for objects that were not yet in the filesystem
+	Updated int    // statements that were reformatted or changed
+	Removed int    // statements for objects that no longer exist in the schema
+	Diff    string // unified diff of updated statements, if Options.ShowDiff
+}
+
+// Count returns the total number of modified statements.
+func (r Result) Count() int {
+	return r.Added + r.Updated + r.Removed
+}
+
 // DumpSchema updates the *.sql files in dir to match the creation statements
 // in schema. Any preexisting creation statements in the dir will be updated to
 // match the canonical format from the live schema. Objects that no longer exist
-// in the live schema will have their statements removed. A count of modified
-// statements is returned, along with any fatal write error. If opts.CountOnly
-// is true, no actual filesystem writes occur, but a count is still returned.
-func DumpSchema(schema *tengo.Schema, dir *fs.Dir, opts Options) (count int, err error) {
+// in the live schema will have their statements removed. Counts of modified
+// statements are returned, along with any fatal write error. If opts.CountOnly
+// is true, no actual filesystem writes occur, but counts are still returned.
+func DumpSchema(schema *tengo.Schema, dir *fs.Dir, opts Options) (result Result, err error) {
 	filesToRewrite := make(map[*fs.TokenizedSQLFile]bool)
-	for key, s := range getStatementMap(schema, dir, opts) {
+	changeNotes := make(map[*fs.TokenizedSQLFile][]string) // only used if opts.DescribeChanges
+	removed := make(map[*fs.Statement]bool)                // only used if opts.DescribeChanges
+	statementMap := getStatementMap(schema, dir, opts)
+
+	// Process objects in a consistent order, so that log output is deterministic
+	keys := make([]tengo.ObjectKey, 0, len(statementMap))
+	for key := range statementMap {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Type != keys[j].Type {
+			return keys[i].Type < keys[j].Type
+		}
+		return keys[i].Name < keys[j].Name
+	})
+
+	for _, key := range keys {
+		s := statementMap[key]
 		if opts.shouldIgnore(key) || s.canonicalCreate == s.filesystemCreate {
 			continue
 		}
 
-		count++
+		if s.fsStatement == nil {
+			result.Added++
+		} else if s.canonicalCreate == "" {
+			result.Removed++
+		} else {
+			result.Updated++
+		}
 		if s.fsStatement != nil {
 			filesToRewrite[s.fsStatement.FromFile] = true
+			if opts.ShowDiff && s.canonicalCreate != "" {
+				fileName := s.fsStatement.FromFile.Path()
+				diff := difflib.UnifiedDiff{
+					A:        difflib.SplitLines(s.filesystemCreate),
+					B:        difflib.SplitLines(s.canonicalCreate),
+					FromFile: fileName,
+					ToFile:   fileName,
+					Context:  3,
+				}
+				diffText, err := difflib.GetUnifiedDiffString(diff)
+				if err != nil {
+					return result, err
+				}
+				if opts.ColorDiff {
+					diffText = colorizeDiff(diffText)
+				}
+				result.Diff += diffText
+			}
 		}
-		if opts.CountOnly {
+		if opts.CountOnly && opts.DescribeChanges {
+			if s.fsStatement == nil {
+				log.Infof("File %s requires addition of %s", fs.PathForObject(dir.Path, key.Name), key)
+			} else if s.canonicalCreate == "" {
+				removed[s.fsStatement] = true
+				changeNotes[s.fsStatement.FromFile] = append(changeNotes[s.fsStatement.FromFile], key.String()+" removed")
+			} else {
+				changeNotes[s.fsStatement.FromFile] = append(changeNotes[s.fsStatement.FromFile], key.String()+" changed")
+			}
+			continue
+		} else if opts.CountOnly {
 			continue
 		}
 
@@ -44,7 +111,7 @@ func DumpSchema(schema *tengo.Schema, dir *fs.Dir, opts Options) (count int, err
 			contents := fs.AddDelimiter(s.canonicalCreate)
 			filePath := fs.PathForObject(dir.Path, key.Name)
 			if err := appendToFile(filePath, contents); err != nil {
-				return count, err
+				return result, err
 			}
 		} else if s.canonicalCreate == "" { // already exists in filesystem, but does not exist in live db schema
 			s.fsStatement.Remove()
@@ -54,15 +121,26 @@ func DumpSchema(schema *tengo.Schema, dir *fs.Dir, opts Options) (count int, err
 	}
 
 	// Do the appropriate rewrites of files tracked above, if requested
+	files := make([]*fs.TokenizedSQLFile, 0, len(filesToRewrite))
 	for file := range filesToRewrite {
-		if opts.CountOnly {
+		files = append(files, file)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Path() < files[j].Path()
+	})
+	for _, file := range files {
+		if opts.CountOnly && opts.DescribeChanges && wouldDelete(file, removed) {
+			log.Infof("File %s would be deleted -- %s", file, strings.Join(changeNotes[file], ", "))
+		} else if opts.CountOnly && opts.DescribeChanges {
+			log.Infof("File %s would be updated -- %s", file, strings.Join(changeNotes[file], ", "))
+		} else if opts.CountOnly {
 			log.Infof("File %s requires formatting changes", file)
 		} else if err := rewriteSQLFile(file); err != nil {
-			return count, err
+			return result, err
 		}
 	}
 
-	return count, nil
+	return result, nil
 }
 
 // getStatementMap builds a mapping of all object keys relevant to this dir,
@@ -135,6 +213,44 @@ func appendToFile(filePath, contents string) error {
 	return nil
 }
 
+// wouldDelete returns true if file would be deleted upon removing the
+// statements in removed, mirroring the logic of TokenizedSQLFile.Rewrite.
+func wouldDelete(file *fs.TokenizedSQLFile, removed map[*fs.Statement]bool) bool {
+	for _, stmt := range file.Statements {
+		if stmt.Type != fs.StatementTypeNoop && stmt.Type != fs.StatementTypeCommand && !removed[stmt] {
+			return false
+		}
+	}
+	return true
+}
+
+// colorizeDiff adds ANSI color codes to the unified-format diff text of a
+// single file: bold for the file headers, cyan for hunk markers, and red or green for removed or added
+// lines.
+func colorizeDiff(diffText string) string {
+	lines := strings.SplitAfter(diffText, "\n")
+	for n, line := range lines {
+		text := strings.TrimSuffix(line, "\n")
+		var code string
+		switch {
+		case text == "":
+			continue
+		case n < 2: // "---" and "+++" file headers
+			code = "1"
+		case strings.HasPrefix(text, "@@"):
+			code = "36"
+		case strings.HasPrefix(text, "-"):
+			code = "31"
+		case strings.HasPrefix(text, "+"):
+			code = "32"
+		default:
+			continue
+		}
+		lines[n] = fmt.Sprintf("\x1b[%sm%s\x1b[0m%s", code, text, line[len(text):])
+	}
+	return strings.Join(lines, "")
+}
+
 // rewriteSQLFile rewrites a TokenizedSQLFile.
 func rewriteSQLFile(file *fs.TokenizedSQLFile) error {
 	if bytesWritten, err := file.Rewrite(); err != nil {
@@ -1,6 +1,7 @@
 package dumper
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/skeema/mybase"
 	"github.com/skeema/skeema/fs"
 	"github.com/skeema/skeema/util"
@@ -61,21 +63,22 @@ func (s IntegrationSuite) TestFormatSimple(t *testing.T) {
 		t.Fatalf("Expected one StatementError from test setup; found %d", len(s.statementErrors))
 	}
 	opts.IgnoreKeys([]tengo.ObjectKey{s.statementErrors[0].ObjectKey()})
-	count, err := DumpSchema(s.schema, s.scratchDir, opts)
+	result, err := DumpSchema(s.schema, s.scratchDir, opts)
 	expected := len(s.scratchDir.LogicalSchemas[0].Creates) - 2 // no reformat needed for table fine, plus one statementerror
-	if count != expected || err != nil {
+	if count := result.Count(); count != expected || err != nil {
 		t.Errorf("Expected FormatLogicalSchema() to return (%d, nil); instead found (%d, %v)", expected, count, err)
 	}
 
 	// Since above run enabled opts.CountOnly, repeated run with it disabled
 	// should return the same count, and another run after that should return 0 count
 	opts.CountOnly = false
-	count, err = DumpSchema(s.schema, s.scratchDir, opts)
-	if count != expected || err != nil {
+	result, err = DumpSchema(s.schema, s.scratchDir, opts)
+	if count := result.Count(); count != expected || err != nil {
 		t.Errorf("Expected FormatLogicalSchema() to return (%d, nil); instead found (%d, %v)", expected, count, err)
 	}
-	count, err = DumpSchema(s.schema, s.scratchDir, opts)
-	if expected = 0; count != expected || err != nil {
+	result, err = DumpSchema(s.schema, s.scratchDir, opts)
+	expected = 0
+	if count := result.Count(); count != expected || err != nil {
 		t.Errorf("Expected FormatLogicalSchema() to return (%d, nil); instead found (%d, %v)", expected, count, err)
 	}
 	s.verifyFormat(t)
@@ -102,27 +105,105 @@ func (s IntegrationSuite) TestFormatPull(t *testing.T) {
 	fs.RemoveTestFile(t, s.testdata(".scratch", "posts.sql"))
 	s.reparseScratchDir(t)
 
-	count, err := DumpSchema(s.schema, s.scratchDir, opts)
+	result, err := DumpSchema(s.schema, s.scratchDir, opts)
 	expected := len(s.scratchDir.LogicalSchemas[0].Creates) - 1 // no reformat needed for fine.sql or invalid.sql, but 1 extra from above manipulations
-	if count != expected || err != nil {
+	if count := result.Count(); count != expected || err != nil {
 		t.Errorf("Expected FormatLogicalSchema() to return (%d, nil); instead found (%d, %v)", expected, count, err)
 	}
+	if result.Added != 1 || result.Removed != 1 {
+		t.Errorf("Expected 1 added and 1 removed statement from renamed table; instead found %+v", result)
+	}
+	if result.Diff != "" {
+		t.Errorf("Expected no diff output without ShowDiff; instead found %q", result.Diff)
+	}
+
+	// With ShowDiff, the diff should cover updated statements, but not the
+	// removed statement for the renamed table
+	opts.ShowDiff = true
+	result, err = DumpSchema(s.schema, s.scratchDir, opts)
+	if result.Diff == "" || err != nil {
+		t.Errorf("Expected diff output with ShowDiff; instead found %q, %v", result.Diff, err)
+	} else if strings.Contains(result.Diff, "widgets") {
+		t.Errorf("Expected diff output to exclude removed statement; instead found %q", result.Diff)
+	}
+	opts.ShowDiff = false
 
 	// Since above run enabled opts.CountOnly, repeated run with it disabled
 	// should return the same count, and another run after that should return 0 count
 	opts.CountOnly = false
-	count, err = DumpSchema(s.schema, s.scratchDir, opts)
-	if count != expected || err != nil {
+	result, err = DumpSchema(s.schema, s.scratchDir, opts)
+	if count := result.Count(); count != expected || err != nil {
 		t.Errorf("Expected FormatLogicalSchema() to return (%d, nil); instead found (%d, %v)", expected, count, err)
 	}
 	s.reparseScratchDir(t)
-	count, err = DumpSchema(s.schema, s.scratchDir, opts)
-	if expected = 0; count != expected || err != nil {
+	result, err = DumpSchema(s.schema, s.scratchDir, opts)
+	expected = 0
+	if count := result.Count(); count != expected || err != nil {
 		t.Errorf("Expected FormatLogicalSchema() to return (%d, nil); instead found (%d, %v)", expected, count, err)
 	}
 	s.verifyFormat(t)
 }
 
+// TestDumpSchemaOrder confirms that DumpSchema processes objects, and rewrites
+// files, in a consistent order regardless of map iteration order.
+func TestDumpSchemaOrder(t *testing.T) {
+	dirPath := filepath.Join("testdata", ".scratch")
+	if err := os.MkdirAll(dirPath, 0777); err != nil {
+		t.Fatalf("Unable to create %s: %s", dirPath, err)
+	}
+	defer os.RemoveAll(dirPath)
+	fs.WriteTestFile(t, filepath.Join(dirPath, "users.sql"), "create table users (id int);\n")
+	fs.WriteTestFile(t, filepath.Join(dirPath, "posts.sql"), "create table posts (id int);\n")
+	fs.WriteTestFile(t, filepath.Join(dirPath, "old.sql"), "create table old (id int);\n")
+	dir, err := getDir(dirPath)
+	if err != nil {
+		t.Fatalf("Unexpected error from getDir: %s", err)
+	}
+	schema := &tengo.Schema{Name: "dumpertest"}
+	for _, name := range []string{"zeta", "users", "alpha", "posts"} {
+		schema.Tables = append(schema.Tables, &tengo.Table{
+			Name:            name,
+			CreateStatement: fmt.Sprintf("CREATE TABLE `%s` (\n  `id` int DEFAULT NULL\n) ENGINE=InnoDB", name),
+		})
+	}
+	schema.Routines = []*tengo.Routine{{
+		Name:            "beta",
+		Type:            tengo.ObjectTypeProc,
+		CreateStatement: "CREATE PROCEDURE `beta`() SELECT 1",
+	}}
+
+	// Object types are processed in order of type name, and then by object name;
+	// files are rewritten in order of path
+	expectOrder := []string{
+		"requires addition of procedure `beta`",
+		"requires addition of table `alpha`",
+		"requires addition of table `zeta`",
+		"old.sql would be deleted -- table `old` removed",
+		"posts.sql would be updated -- table `posts` changed",
+		"users.sql would be updated -- table `users` changed",
+	}
+	opts := Options{CountOnly: true, DescribeChanges: true}
+	origOut := log.StandardLogger().Out
+	defer log.SetOutput(origOut)
+	for n := 0; n < 5; n++ {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		result, err := DumpSchema(schema, dir, opts)
+		if err != nil || result.Added != 3 || result.Updated != 2 || result.Removed != 1 {
+			t.Fatalf("Unexpected return from DumpSchema: %+v, %v", result, err)
+		}
+		output := buf.String()
+		var prevPos int
+		for _, expected := range expectOrder {
+			pos := strings.Index(output, expected)
+			if pos < prevPos {
+				t.Fatalf("Expected output to contain %q after position %d; instead found at %d. Full output:\n%s", expected, prevPos, pos, output)
+			}
+			prevPos = pos
+		}
+	}
+}
+
 func (s *IntegrationSuite) Setup(backend string) (err error) {
 	s.d, err = s.manager.GetOrCreateInstance(tengo.DockerizedInstanceOptions{
 		Name:         fmt.Sprintf("skeema-test-%s", strings.Replace(backend, ":", "-", -1)),
@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"sync"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/skeema/skeema/util"
@@ -61,8 +62,18 @@ func ExitCode(err error) int {
 	return CodeFatalError
 }
 
+var exitOnce sync.Once
+
 // Exit terminates the program with the appropriate exit code and log output.
+// It is safe to call from multiple goroutines, for example a signal handler
+// and main(); only the first call has any effect, and later calls block until
+// the program exits.
 func Exit(err error) {
+	exitOnce.Do(func() { exit(err) })
+	select {} // only reached by concurrent calls, while the first call exits
+}
+
+func exit(err error) {
 	exitCode := ExitCode(err)
 	if err == nil {
 		log.Debug("Exit code 0 (SUCCESS)")
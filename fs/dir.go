@@ -29,6 +29,7 @@ type Dir struct {
 	ParseError        error            // any fatal error found parsing dir's config or contents
 	IgnoredStatements []*Statement     // statements with unknown type / not supported by this package
 	repoBase          string           // absolute path of containing repo, or topmost-found .skeema file
+	ignoreRules       []ignoreRule     // rules from .skeemaignore files in this dir's crawled ancestors
 }
 
 // LogicalSchema represents a set of statements from *.sql files in a directory
@@ -128,6 +129,32 @@ func (dir *Dir) Delete() error {
 	return os.RemoveAll(dir.Path)
 }
 
+// DeleteManagedFiles unlinks the *.sql files and .skeema option file in dir.
+// If nothing else remains in the directory afterwards, the directory itself is
+// also removed. Otherwise, the directory is kept, and the names of any
+// remaining entries are returned.
+func (dir *Dir) DeleteManagedFiles() (remaining []string, err error) {
+	for _, sf := range dir.SQLFiles {
+		if err := sf.Delete(); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	if err := os.Remove(path.Join(dir.Path, ".skeema")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	fileInfos, err := ioutil.ReadDir(dir.Path)
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range fileInfos {
+		remaining = append(remaining, fi.Name())
+	}
+	if len(remaining) > 0 {
+		return remaining, nil
+	}
+	return nil, os.Remove(dir.Path)
+}
+
 // HasFile returns true if the specified filename exists in dir.
 func (dir *Dir) HasFile(name string) (bool, error) {
 	_, err := os.Lstat(path.Join(dir.Path, name))
@@ -140,8 +167,10 @@ func (dir *Dir) HasFile(name string) (bool, error) {
 }
 
 // Subdirs reads the list of direct, non-hidden subdirectories of dir, parses
-// them (*.sql and .skeema files), and returns them. An error will be returned
-// if there are problems reading dir's the directory list. Otherwise, err is
+// them (*.sql and .skeema files), and returns them. Subdirectories matching a
+// pattern in a .skeemaignore file, in dir or any ancestor reached by recursing
+// through Subdirs, are skipped. An error will be returned if there are problems
+// reading dir's the directory list or .skeemaignore file. Otherwise, err is
 // nil, but some of the returned Dir values will have a non-nil ParseError if
 // any problems were encountered in that subdir.
 func (dir *Dir) Subdirs() ([]*Dir, error) {
@@ -149,13 +178,24 @@ func (dir *Dir) Subdirs() ([]*Dir, error) {
 	if err != nil {
 		return nil, err
 	}
+	rules, err := parseIgnoreFile(dir.Path)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, dir.ignoreRules...)
 	result := make([]*Dir, 0, len(fileInfos))
 	for _, fi := range fileInfos {
 		if fi.IsDir() && fi.Name()[0] != '.' {
+			subPath := path.Join(dir.Path, fi.Name())
+			if ignoredByRules(subPath, rules) {
+				log.Debugf("Skipping %s due to .skeemaignore", subPath)
+				continue
+			}
 			sub := &Dir{
-				Path:     path.Join(dir.Path, fi.Name()),
-				Config:   dir.Config.Clone(),
-				repoBase: dir.repoBase,
+				Path:        subPath,
+				Config:      dir.Config.Clone(),
+				repoBase:    dir.repoBase,
+				ignoreRules: rules,
 			}
 			sub.parseContents()
 			result = append(result, sub)
@@ -282,7 +322,10 @@ func (dir *Dir) Instances() ([]*tengo.Instance, error) {
 	if err != nil {
 		return nil, fmt.Errorf("Invalid connection options: %s", err)
 	}
-	portValue := dir.Config.GetIntOrDefault("port")
+	portValue, err := dir.Config.GetInt("port")
+	if err != nil || portValue < 1 || portValue > 65535 {
+		return nil, fmt.Errorf("Option port must be an integer between 1 and 65535, but %q was supplied", dir.Config.Get("port"))
+	}
 	portWasSupplied := dir.Config.Supplied("port")
 	portIsntDefault := dir.Config.Changed("port")
 	socketValue := dir.Config.Get("socket")
@@ -335,10 +378,12 @@ func (dir *Dir) FirstInstance() (*tengo.Instance, error) {
 	}
 
 	var lastErr error
-	for _, instance := range instances {
+	for n, instance := range instances {
 		var ok bool
 		if ok, lastErr = instance.CanConnect(); ok {
 			return instance, nil
+		} else if n < len(instances)-1 {
+			log.Warnf("Unable to connect to %s for %s: %s; trying next instance", instance, dir, lastErr)
 		}
 	}
 	if len(instances) == 1 {
@@ -415,17 +460,11 @@ func (dir *Dir) SchemaNames(instance *tengo.Instance) (names []string, err error
 	if err != nil {
 		return nil, err
 	}
-	systemSchemas := map[string]bool{
-		"information_schema": true,
-		"performance_schema": true,
-		"sys":                true,
-		"mysql":              true,
-	}
 	keepNames := make([]string, 0, len(names))
 	for _, name := range names {
 		if ignoreSchema != nil && ignoreSchema.MatchString(name) {
 			log.Debugf("Skipping schema %s because ignore-schema='%s'", name, ignoreSchema)
-		} else if !systemSchemas[strings.ToLower(name)] {
+		} else if !util.IsSystemSchema(name) {
 			keepNames = append(keepNames, name)
 		}
 	}
@@ -511,6 +550,19 @@ func (dir *Dir) InstanceDefaultParams() (string, error) {
 		v.Set(name, value)
 	}
 
+	// Set TLS param based on ssl-mode and related options, if any
+	tlsValue, err := util.RegisterTLSConfig(dir.Config.Get("ssl-mode"), dir.Config.Get("ssl-ca"), dir.Config.Get("ssl-cert"), dir.Config.Get("ssl-key"))
+	if err != nil {
+		return "", err
+	} else if tlsValue != "" {
+		for name := range options {
+			if strings.ToLower(name) == "tls" {
+				return "", fmt.Errorf("connect-options may not contain %s when ssl-mode is also set", name)
+			}
+		}
+		v.Set("tls", tlsValue)
+	}
+
 	// Set non-overridable options
 	v.Set("interpolateParams", "true")
 	v.Set("foreign_key_checks", "0")
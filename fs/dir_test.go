@@ -150,6 +150,47 @@ func TestDirRelPath(t *testing.T) {
 	}
 }
 
+func TestDirDeleteManagedFiles(t *testing.T) {
+	fillDir := func(extraFiles ...string) *Dir {
+		t.Helper()
+		WriteTestFile(t, "testdata/.scratch/del/.skeema", "schema=foo\n")
+		WriteTestFile(t, "testdata/.scratch/del/users.sql", "CREATE TABLE users (id int);\n")
+		WriteTestFile(t, "testdata/.scratch/del/posts.sql", "CREATE TABLE posts (id int);\n")
+		for _, name := range extraFiles {
+			WriteTestFile(t, "testdata/.scratch/del/"+name, "hello world")
+		}
+		return getDir(t, "testdata/.scratch/del")
+	}
+
+	// Extra files should be reported, and prevent removal of the dir
+	dir := fillDir("README", "notes.txt")
+	remaining, err := dir.DeleteManagedFiles()
+	if err != nil {
+		t.Fatalf("Unexpected error from DeleteManagedFiles: %s", err)
+	} else if len(remaining) != 2 || remaining[0] != "README" || remaining[1] != "notes.txt" {
+		t.Errorf("Unexpected remaining files returned: %v", remaining)
+	}
+	for _, name := range []string{".skeema", "users.sql", "posts.sql"} {
+		if has, err := dir.HasFile(name); has || err != nil {
+			t.Errorf("Expected %s to be deleted, but HasFile returned %t / %v", name, has, err)
+		}
+	}
+	if has, err := dir.HasFile("README"); !has || err != nil {
+		t.Errorf("Expected README to be kept, but HasFile returned %t / %v", has, err)
+	}
+
+	// Without extra files, the dir itself should be removed
+	RemoveTestDirectory(t, "testdata/.scratch/del")
+	dir = fillDir()
+	if remaining, err = dir.DeleteManagedFiles(); len(remaining) > 0 || err != nil {
+		t.Errorf("Unexpected return from DeleteManagedFiles: %v / %v", remaining, err)
+	}
+	if _, err := os.Stat(dir.Path); !os.IsNotExist(err) {
+		t.Errorf("Expected dir to be removed, but os.Stat returned %v", err)
+	}
+	RemoveTestDirectory(t, "testdata/.scratch")
+}
+
 func TestDirSubdirs(t *testing.T) {
 	dir := getDir(t, "../testdata/golden/init/mydb")
 	subs, err := dir.Subdirs()
@@ -168,6 +209,119 @@ func TestDirSubdirs(t *testing.T) {
 	}
 }
 
+func TestDirSubdirsInheritance(t *testing.T) {
+	WriteTestFile(t, "testdata/.scratch/top/.skeema", "host=top.db.host\nport=3307\ndefault-character-set=latin1\n")
+	WriteTestFile(t, "testdata/.scratch/top/mid/.skeema", "port=3308\n[production]\nhost=mid.db.host\n")
+	WriteTestFile(t, "testdata/.scratch/top/mid/leaf/.skeema", "schema=leafdb\ndefault-character-set=utf8mb4\n")
+	defer RemoveTestDirectory(t, "testdata/.scratch")
+
+	dir := getDir(t, "testdata/.scratch/top")
+	for _, name := range []string{"mid", "leaf"} {
+		subs, err := dir.Subdirs()
+		if err != nil || len(subs) != 1 || countParseErrors(subs) > 0 {
+			t.Fatalf("Unexpected return from Subdirs() of %s: %d subs, err=%v", dir, len(subs), err)
+		} else if subs[0].BaseName() != name {
+			t.Fatalf("Expected subdir of %s to be %s, instead found %s", dir, name, subs[0].BaseName())
+		}
+		dir = subs[0]
+	}
+
+	// Options set at any level should be inherited by the leaf, with the closest
+	// dir's value taking precedence
+	expected := map[string]string{
+		"host":                  "mid.db.host",
+		"port":                  "3308",
+		"schema":                "leafdb",
+		"default-character-set": "utf8mb4",
+	}
+	for option, value := range expected {
+		if actual := dir.Config.Get(option); actual != value {
+			t.Errorf("Expected %s to have %s=%q, instead found %q", dir, option, value, actual)
+		}
+	}
+}
+
+func TestDirPasswordFilePrecedence(t *testing.T) {
+	WriteTestFile(t, "testdata/.scratch/pwfile", "filepw\n")
+	WriteTestFile(t, "testdata/.scratch/pw/.skeema", "host=some.db.host\n")
+	WriteTestFile(t, "testdata/.scratch/pw/leaf/.skeema", "schema=foo\npassword=dirpw\n")
+	defer RemoveTestDirectory(t, "testdata/.scratch")
+
+	cmd := mybase.NewCommand("fstest", "", "", nil)
+	util.AddGlobalOptions(cmd)
+	cmd.AddArg("environment", "production", false)
+	cfg := mybase.ParseFakeCLI(t, cmd, "fstest --password-file=testdata/.scratch/pwfile")
+	if err := util.ProcessSpecialGlobalOptions(cfg); err != nil {
+		t.Fatalf("Unexpected error from ProcessSpecialGlobalOptions: %s", err)
+	}
+
+	// password-file should apply to a dir that doesn't set password, but a
+	// password in a per-dir .skeema file should take precedence over it
+	dir, err := ParseDir("testdata/.scratch/pw", cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDir: %s", err)
+	} else if actual := dir.Config.Get("password"); actual != "filepw" {
+		t.Errorf("Expected password to come from password-file, instead found %q", actual)
+	}
+	dir, err = ParseDir("testdata/.scratch/pw/leaf", cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDir: %s", err)
+	} else if actual := dir.Config.Get("password"); actual != "dirpw" {
+		t.Errorf("Expected password from .skeema file to take precedence over password-file, instead found %q", actual)
+	}
+}
+
+func TestDirSubdirsIgnore(t *testing.T) {
+	WriteTestFile(t, "testdata/.scratch/ign/.skeemaignore", "# comment\n\ntemplates/\n/scratch/old\n")
+	for _, sub := range []string{"templates", "scratch/old", "scratch/new", "app/templates", "app/old"} {
+		WriteTestFile(t, "testdata/.scratch/ign/"+sub+"/README", "hello world")
+	}
+	defer RemoveTestDirectory(t, "testdata/.scratch")
+
+	subdirNames := func(dir *Dir) []string {
+		t.Helper()
+		subs, err := dir.Subdirs()
+		if err != nil || countParseErrors(subs) > 0 {
+			t.Fatalf("Unexpected error from Subdirs(): %v", err)
+		}
+		names := make([]string, len(subs))
+		for n, sub := range subs {
+			names[n] = sub.BaseName()
+		}
+		return names
+	}
+	findSub := func(dir *Dir, name string) *Dir {
+		t.Helper()
+		subs, _ := dir.Subdirs()
+		for _, sub := range subs {
+			if sub.BaseName() == name {
+				return sub
+			}
+		}
+		t.Fatalf("Subdir %s not found in %s", name, dir)
+		return nil
+	}
+
+	// Unanchored patterns match at any depth; anchored patterns only match
+	// relative to the location of the .skeemaignore file
+	dir := getDir(t, "testdata/.scratch/ign")
+	if names := subdirNames(dir); len(names) != 2 || names[0] != "app" || names[1] != "scratch" {
+		t.Errorf("Unexpected subdirs of %s: %v", dir, names)
+	}
+	if names := subdirNames(findSub(dir, "app")); len(names) != 1 || names[0] != "old" {
+		t.Errorf("Unexpected subdirs of app: %v", names)
+	}
+	if names := subdirNames(findSub(dir, "scratch")); len(names) != 1 || names[0] != "new" {
+		t.Errorf("Unexpected subdirs of scratch: %v", names)
+	}
+
+	// Invalid patterns should cause an error
+	WriteTestFile(t, "testdata/.scratch/ign/.skeemaignore", "[\n")
+	if _, err := dir.Subdirs(); err == nil {
+		t.Error("Expected error from invalid .skeemaignore pattern, but err was nil")
+	}
+}
+
 func TestDirInstances(t *testing.T) {
 	assertInstances := func(optionValues map[string]string, expectError bool, expectedInstances ...string) []*tengo.Instance {
 		cmd := mybase.NewCommand("test", "1.0", "this is for testing", nil)
@@ -221,6 +375,9 @@ func TestDirInstances(t *testing.T) {
 	assertInstances(map[string]string{"host": "some.db.host", "connect-options": ","}, true)
 	assertInstances(map[string]string{"host": "some.db.host:3306", "port": "3307"}, true)
 	assertInstances(map[string]string{"host": "@@@@@"}, true)
+	assertInstances(map[string]string{"host": "some.db.host", "port": "abc"}, true)
+	assertInstances(map[string]string{"host": "some.db.host", "port": "70000"}, true)
+	assertInstances(map[string]string{"host": "some.db.host:abc"}, true)
 	assertInstances(map[string]string{"host-wrapper": "`echo {INVALID_VAR}`", "host": "irrelevant"}, true)
 
 	// dynamic hosts via host-wrapper command execution
@@ -233,12 +390,22 @@ func TestDirInstances(t *testing.T) {
 }
 
 func TestDirInstanceDefaultParams(t *testing.T) {
-	getDir := func(connectOptions, flavor string) *Dir {
+	getDirWithSSLMode := func(connectOptions, flavor, sslMode string) *Dir {
 		return &Dir{
-			Path:   "/tmp/dummydir",
-			Config: mybase.SimpleConfig(map[string]string{"connect-options": connectOptions, "flavor": flavor}),
+			Path: "/tmp/dummydir",
+			Config: mybase.SimpleConfig(map[string]string{
+				"connect-options": connectOptions,
+				"flavor":          flavor,
+				"ssl-mode":        sslMode,
+				"ssl-ca":          "",
+				"ssl-cert":        "",
+				"ssl-key":         "",
+			}),
 		}
 	}
+	getDir := func(connectOptions, flavor string) *Dir {
+		return getDirWithSSLMode(connectOptions, flavor, "disabled")
+	}
 
 	assertDefaultParams := func(connectOptions, flavor, expected string) {
 		t.Helper()
@@ -285,6 +452,23 @@ func TestDirInstanceDefaultParams(t *testing.T) {
 			t.Errorf("Did not get expected error from connect-options=\"%s\"", connOpts)
 		}
 	}
+
+	// Confirm ssl-mode is reflected in the tls param, and conflicts with an
+	// explicit tls value in connect-options
+	dir := getDirWithSSLMode("", "", "preferred")
+	if params, err := dir.InstanceDefaultParams(); err != nil {
+		t.Errorf("Unexpected error from ssl-mode=preferred: %s", err)
+	} else if parsed, _ := url.ParseQuery(params); parsed.Get("tls") != "preferred" {
+		t.Errorf("Expected ssl-mode=preferred to yield tls=preferred, instead found params %s", params)
+	}
+	dir = getDirWithSSLMode("tls=true", "", "preferred")
+	if _, err := dir.InstanceDefaultParams(); err == nil {
+		t.Error("Expected error from combining ssl-mode with tls in connect-options, but err was nil")
+	}
+	dir = getDirWithSSLMode("", "", "sometimes")
+	if _, err := dir.InstanceDefaultParams(); err == nil {
+		t.Error("Expected error from invalid ssl-mode, but err was nil")
+	}
 }
 
 func getValidConfig(t *testing.T) *mybase.Config {
@@ -0,0 +1,75 @@
+package fs
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ignoreRule represents a single pattern from a .skeemaignore file. Patterns
+// containing a slash are matched against the subdirectory path relative to the
+// location of the .skeemaignore file; other patterns are matched against the
+// base name of subdirectories at any depth below that location.
+type ignoreRule struct {
+	base     string // absolute path of the dir containing the .skeemaignore file
+	pattern  string
+	anchored bool
+}
+
+// match returns true if the rule matches the supplied absolute directory path.
+func (rule ignoreRule) match(dirPath string) bool {
+	if !rule.anchored {
+		matched, _ := path.Match(rule.pattern, path.Base(dirPath))
+		return matched
+	}
+	rel, err := filepath.Rel(rule.base, dirPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return false
+	}
+	matched, _ := path.Match(rule.pattern, filepath.ToSlash(rel))
+	return matched
+}
+
+// parseIgnoreFile reads the .skeemaignore file in dirPath, if one exists, and
+// returns its rules. Blank lines and lines beginning with # are skipped.
+func parseIgnoreFile(dirPath string) ([]ignoreRule, error) {
+	f, err := os.Open(path.Join(dirPath, ".skeemaignore"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		pattern := strings.Trim(line, "/")
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("%s: invalid pattern %q: %s", path.Join(dirPath, ".skeemaignore"), line, err)
+		}
+		rules = append(rules, ignoreRule{
+			base:     dirPath,
+			pattern:  pattern,
+			anchored: strings.Contains(pattern, "/") || line[0] == '/',
+		})
+	}
+	return rules, scanner.Err()
+}
+
+// ignoredByRules returns true if any of rules match dirPath.
+func ignoredByRules(dirPath string, rules []ignoreRule) bool {
+	for _, rule := range rules {
+		if rule.match(dirPath) {
+			return true
+		}
+	}
+	return false
+}
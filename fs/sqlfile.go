@@ -5,9 +5,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/skeema/tengo"
 )
 
 // SQLFile represents a file containing zero or more SQL statements.
@@ -50,7 +53,7 @@ func (sf SQLFile) Create(contents string) error {
 	} else if exists {
 		return fmt.Errorf("Cannot create %s: already exists", sf)
 	}
-	return ioutil.WriteFile(sf.Path(), []byte(contents), 0666)
+	return writeFileAtomic(sf.Path(), []byte(contents))
 }
 
 // Delete unlinks the file.
@@ -112,7 +115,7 @@ func (sf SQLFile) WriteStatements(statements []*Statement) (int, error) {
 		lines[n] = string(statements[n].Text)
 	}
 	value := strings.Join(lines, "")
-	err := ioutil.WriteFile(sf.Path(), []byte(value), 0666)
+	err := writeFileAtomic(sf.Path(), []byte(value))
 	if err != nil {
 		return 0, err
 	}
@@ -151,6 +154,31 @@ func (tsf *TokenizedSQLFile) Rewrite() (int, error) {
 	return 0, tsf.Delete()
 }
 
+// TableName returns the name of the table created by the file, if the file
+// contains exactly one CREATE TABLE statement. Otherwise, an empty string is
+// returned. Since this relies on the statement tokenizer, leading comments and
+// any identifier quoting style are handled the same way as elsewhere.
+func (tsf *TokenizedSQLFile) TableName() string {
+	var name string
+	for _, stmt := range tsf.Statements {
+		if stmt.Type == StatementTypeCreate && stmt.ObjectType == tengo.ObjectTypeTable {
+			if name != "" {
+				return ""
+			}
+			name = stmt.ObjectName
+		}
+	}
+	return name
+}
+
+// FileNameMatchesTable returns false if the file contains exactly one
+// CREATE TABLE statement, and the file's name does not correspond to that
+// table's name. In all other cases, true is returned.
+func (tsf *TokenizedSQLFile) FileNameMatchesTable() bool {
+	name := tsf.TableName()
+	return name == "" || PathForObject("", name) == tsf.FileName
+}
+
 // PathForObject returns a string containing a path to use for the SQLFile
 // representing the supplied object name. Special characters in the objectName
 // will be removed; however, there is no risk of "conflicts" since a single
@@ -188,7 +216,7 @@ func removeSpecialChars(r rune) rune {
 func AppendToFile(filePath, contents string) (bytesWritten int, created bool, err error) {
 	_, err = os.Stat(filePath)
 	if os.IsNotExist(err) {
-		return len(contents), true, ioutil.WriteFile(filePath, []byte(contents), 0666)
+		return len(contents), true, writeFileAtomic(filePath, []byte(contents))
 	} else if err != nil {
 		return
 	}
@@ -202,7 +230,56 @@ func AppendToFile(filePath, contents string) (bytesWritten int, created bool, er
 		whitespace = "\n"
 	}
 	newContents := fmt.Sprintf("%s%s%s", string(byteContents), whitespace, contents)
-	return len(newContents), false, ioutil.WriteFile(filePath, []byte(newContents), 0666)
+	return len(newContents), false, writeFileAtomic(filePath, []byte(newContents))
+}
+
+// writeFileAtomic writes data to a temporary file in the same directory as
+// filePath, and then renames it into place. This way, if the process is
+// interrupted, filePath will never be left in a partially-written state. If
+// filePath already exists, its permissions are preserved; otherwise, the file
+// gets the same permissions that ioutil.WriteFile(filePath, data, 0666) would
+// have used. If filePath is a symlink, the symlink's target is replaced instead.
+func writeFileAtomic(filePath string, data []byte) (err error) {
+	if resolved, err := filepath.EvalSymlinks(filePath); err == nil {
+		filePath = resolved
+	}
+	perm := os.FileMode(0666)
+	var existingPerm bool
+	if fi, err := os.Stat(filePath); err == nil {
+		perm = fi.Mode().Perm()
+		existingPerm = true
+	}
+
+	var f *os.File
+	var tempPath string
+	for attempt := 0; f == nil; attempt++ {
+		tempPath = path.Join(path.Dir(filePath), fmt.Sprintf(".%s.%d.%d.tmp", path.Base(filePath), os.Getpid(), attempt))
+		f, err = os.OpenFile(tempPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+		if err != nil && (!os.IsExist(err) || attempt >= 100) {
+			return err
+		}
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(tempPath)
+		}
+	}()
+
+	if _, err = f.Write(data); err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil && existingPerm {
+		// OpenFile's perm is subject to umask, so explicitly restore the
+		// existing file's permissions
+		err = os.Chmod(tempPath, perm)
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tempPath, filePath)
 }
 
 var reIsMultiStatement = regexp.MustCompile(`(?is)begin.*;.*end`)
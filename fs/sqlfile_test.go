@@ -1,6 +1,8 @@
 package fs
 
 import (
+	"io/ioutil"
+	"os"
 	"strings"
 	"testing"
 
@@ -242,6 +244,55 @@ func expectedStatements(filePath string) []*Statement {
 	}
 }
 
+func TestTokenizedSQLFileTableName(t *testing.T) {
+	// statements.sql creates multiple tables, so no single table name
+	sf := SQLFile{
+		Dir:      "testdata",
+		FileName: "statements.sql",
+	}
+	tokenizedFile, err := sf.Tokenize()
+	if err != nil {
+		t.Fatalf("Unexpected error from Tokenize(): %s", err)
+	}
+	if name := tokenizedFile.TableName(); name != "" {
+		t.Errorf("Expected TableName() to return empty string, instead found %q", name)
+	}
+	if !tokenizedFile.FileNameMatchesTable() {
+		t.Error("Expected FileNameMatchesTable() to return true for multi-table file, but it returned false")
+	}
+
+	cases := []struct {
+		contents string
+		expected string
+		matches  bool
+	}{
+		{"CREATE TABLE users (id int);\n", "users", true},
+		{"CREATE TABLE IF NOT EXISTS users (id int);\n", "users", true},
+		{"-- leading comment\n/* another */ CREATE TABLE `users` (id int);\n", "users", true},
+		{"CREATE TABLE `accounts` (id int);\n", "accounts", false},
+		{"CREATE PROCEDURE foo() SELECT 1;\n", "", true},
+	}
+	for _, c := range cases {
+		WriteTestFile(t, "testdata/.scratch/users.sql", c.contents)
+		sf := SQLFile{
+			Dir:      "testdata/.scratch",
+			FileName: "users.sql",
+		}
+		tokenizedFile, err := sf.Tokenize()
+		if err != nil {
+			t.Fatalf("Unexpected error from Tokenize(): %s", err)
+		}
+		if name := tokenizedFile.TableName(); name != c.expected {
+			t.Errorf("Contents %q: expected TableName() to return %q, instead found %q", c.contents, c.expected, name)
+		}
+		if matches := tokenizedFile.FileNameMatchesTable(); matches != c.matches {
+			t.Errorf("Contents %q: expected FileNameMatchesTable() to return %t, instead found %t", c.contents, c.matches, matches)
+		}
+	}
+	RemoveTestFile(t, "testdata/.scratch/users.sql")
+	RemoveTestFile(t, "testdata/.scratch")
+}
+
 func TestPathForObject(t *testing.T) {
 	cases := []struct {
 		DirPath    string
@@ -290,6 +341,56 @@ func TestAppendToFile(t *testing.T) {
 	RemoveTestFile(t, "testdata/.scratch")
 }
 
+func TestWriteFileAtomic(t *testing.T) {
+	// New file should get same permissions as a direct create
+	MakeTestDirectory(t, "testdata/.scratch")
+	if err := ioutil.WriteFile("testdata/.scratch/direct", []byte("hello world"), 0666); err != nil {
+		t.Fatalf("Unable to write file: %s", err)
+	}
+	if err := writeFileAtomic("testdata/.scratch/atomic", []byte("hello world")); err != nil {
+		t.Fatalf("Unexpected error from writeFileAtomic: %s", err)
+	}
+	directFI, _ := os.Stat("testdata/.scratch/direct")
+	atomicFI, _ := os.Stat("testdata/.scratch/atomic")
+	if directFI.Mode().Perm() != atomicFI.Mode().Perm() {
+		t.Errorf("Expected new file permissions %s, instead found %s", directFI.Mode().Perm(), atomicFI.Mode().Perm())
+	}
+	if contents := ReadTestFile(t, "testdata/.scratch/atomic"); contents != "hello world" {
+		t.Errorf("Unexpected contents: %s", contents)
+	}
+
+	// Existing file should retain its permissions
+	if err := os.Chmod("testdata/.scratch/atomic", 0640); err != nil {
+		t.Fatalf("Unable to chmod: %s", err)
+	}
+	if err := writeFileAtomic("testdata/.scratch/atomic", []byte("hi")); err != nil {
+		t.Fatalf("Unexpected error from writeFileAtomic: %s", err)
+	}
+	if fi, _ := os.Stat("testdata/.scratch/atomic"); fi.Mode().Perm() != 0640 {
+		t.Errorf("Expected permissions of existing file to be retained, instead found %s", fi.Mode().Perm())
+	}
+
+	// Writing to a symlink should replace the target's contents, not the symlink
+	if err := os.Symlink("atomic", "testdata/.scratch/link"); err != nil {
+		t.Fatalf("Unable to create symlink: %s", err)
+	}
+	if err := writeFileAtomic("testdata/.scratch/link", []byte("via link")); err != nil {
+		t.Fatalf("Unexpected error from writeFileAtomic: %s", err)
+	}
+	if fi, err := os.Lstat("testdata/.scratch/link"); err != nil || fi.Mode()&os.ModeSymlink == 0 {
+		t.Errorf("Expected symlink to be retained, instead found %v / %v", fi, err)
+	}
+	if contents := ReadTestFile(t, "testdata/.scratch/atomic"); contents != "via link" {
+		t.Errorf("Unexpected contents: %s", contents)
+	}
+
+	// No temp files should be left behind
+	if fileInfos, err := ioutil.ReadDir("testdata/.scratch"); err != nil || len(fileInfos) != 3 {
+		t.Errorf("Expected 3 entries in testdata/.scratch, instead found %d (err=%v)", len(fileInfos), err)
+	}
+	RemoveTestDirectory(t, "testdata/.scratch")
+}
+
 func TestAddDelimiter(t *testing.T) {
 	proc := `CREATE PROCEDURE whatever(name varchar(10))
 BEGIN
@@ -5,11 +5,13 @@ go 1.12
 require (
 	github.com/VividCortex/mysqlerr v0.0.0-20170204212430-6c6b55f8796f
 	github.com/alecthomas/participle v0.3.0
+	github.com/go-sql-driver/mysql v1.5.0
 	github.com/jmoiron/sqlx v1.2.0
 	github.com/mattn/goveralls v0.0.3-0.20190605103025-4d9899298d21
 	github.com/mitchellh/go-wordwrap v1.0.0
 	github.com/nozzle/throttler v0.0.0-20180817012639-2ea982251481
 	github.com/opencontainers/runc v1.0.0-rc5 // indirect
+	github.com/pmezard/go-difflib v1.0.0
 	github.com/sirupsen/logrus v1.4.2
 	github.com/skeema/mybase v1.0.8
 	github.com/skeema/tengo v0.9.2
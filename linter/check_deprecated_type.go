@@ -0,0 +1,79 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/skeema/tengo"
+)
+
+func init() {
+	RegisterRule(Rule{
+		CheckerFunc:     TableChecker(deprecatedTypeChecker),
+		Name:            "deprecated-type",
+		Description:     "Flag columns using data types or attributes that are deprecated or removed in newer MySQL versions",
+		DefaultSeverity: SeverityIgnore,
+	})
+}
+
+// deprecatedType describes a column type pattern which is deprecated or
+// removed in some version of MySQL. Patterns are matched against
+// tengo.Column.TypeInDB. Integer display widths are intentionally omitted
+// here, since the display-width rule already handles them.
+type deprecatedType struct {
+	re          *regexp.Regexp
+	description string
+	version     string
+	removed     bool
+}
+
+var deprecatedTypes = []deprecatedType{
+	{
+		re:          regexp.MustCompile(`^year\(2\)`),
+		description: "YEAR(2)",
+		version:     "5.7.5",
+		removed:     true,
+	},
+	{
+		re:          regexp.MustCompile(`^(float|double)\(\d+,\d+\)`),
+		description: "FLOAT(M,D) and DOUBLE(M,D) precision syntax",
+		version:     "8.0.17",
+	},
+	{
+		re:          regexp.MustCompile(`^(float|double|decimal)\b.* unsigned`),
+		description: "the UNSIGNED attribute for FLOAT, DOUBLE, and DECIMAL",
+		version:     "8.0.17",
+	},
+	{
+		re:          regexp.MustCompile(` zerofill`),
+		description: "the ZEROFILL attribute",
+		version:     "8.0.17",
+	},
+}
+
+func deprecatedTypeChecker(table *tengo.Table, createStatement string, _ *tengo.Schema, _ Options) []Note {
+	results := make([]Note, 0)
+	for _, col := range table.Columns {
+		for _, dt := range deprecatedTypes {
+			if !dt.re.MatchString(col.TypeInDB) {
+				continue
+			}
+			verb := "deprecated"
+			if dt.removed {
+				verb = "removed"
+			}
+			re := regexp.MustCompile(fmt.Sprintf(`\b%s\b`, regexp.QuoteMeta(col.Name)))
+			message := fmt.Sprintf(
+				"Column %s of table %s is using type %s. MySQL %s %s support for %s, so this column definition should be changed before upgrading.",
+				col.Name, table.Name, col.TypeInDB, dt.version, verb, dt.description,
+			)
+			results = append(results, Note{
+				LineOffset: FindFirstLineOffset(re, createStatement),
+				Summary:    "Column using deprecated type",
+				Message:    message,
+			})
+			break // only report the first match for each column
+		}
+	}
+	return results
+}
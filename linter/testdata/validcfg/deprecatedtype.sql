@@ -0,0 +1,8 @@
+CREATE TABLE `deprecatedtype` (
+  id int(10) unsigned NOT NULL,
+  yr year,
+  dec_signed decimal(10,2),
+  dec_unsigned decimal(10,2) unsigned, /* annotations: deprecated-type */
+  dbl_unsigned double unsigned, /* annotations: has-float, deprecated-type */
+  PRIMARY KEY (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
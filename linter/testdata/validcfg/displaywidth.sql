@@ -23,8 +23,8 @@ CREATE TABLE `displaywidth` (
   booly bool,
   alsobool tinyint(1),
   alsoboolu tinyint(1) unsigned,
-  padded int(5) zerofill,
-  paddedu int(4) unsigned zerofill,
+  padded int(5) zerofill,            /* annotations: deprecated-type */
+  paddedu int(4) unsigned zerofill,  /* annotations: deprecated-type */
   
   PRIMARY KEY (`id`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4
\ No newline at end of file
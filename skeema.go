@@ -3,10 +3,13 @@ package main
 import (
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime/debug"
+	"syscall"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/skeema/mybase"
+	"github.com/skeema/skeema/fs"
 	"github.com/skeema/skeema/util"
 	"github.com/skeema/skeema/workspace"
 )
@@ -45,11 +48,25 @@ func main() {
 		Exit(NewExitValue(CodeBadConfig, err.Error()))
 	}
 
-	util.AddGlobalConfigFiles(cfg)
+	if err := util.AddGlobalConfigFiles(cfg); err != nil {
+		Exit(NewExitValue(CodeBadConfig, err.Error()))
+	}
 	if err := util.ProcessSpecialGlobalOptions(cfg); err != nil {
 		Exit(NewExitValue(CodeBadConfig, err.Error()))
 	}
 
+	// If interrupted, clean up any workspaces (temp schemas, containers) before
+	// exiting, rather than leaving them behind on the server
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		sig := <-sigChan
+		signal.Stop(sigChan) // a second signal will terminate immediately, without cleanup
+		log.Warnf("Received signal %s; cleaning up before exiting", sig)
+		workspace.Shutdown()
+		Exit(NewExitValue(CodeFatalError, "Interrupted by signal %s", sig))
+	}()
+
 	err = cfg.HandleCommand()
 	workspace.Shutdown()
 	Exit(err)
@@ -61,3 +78,23 @@ func versionString() string {
 	}
 	return fmt.Sprintf("%s, commit %s, released %s", version, commit, date)
 }
+
+// parseBaseDir returns the directory that a command should operate on, based
+// on the --dir option. The path may be relative to the working directory or
+// absolute, and defaults to ".". The command then behaves as if it had been run
+// from inside that directory: the returned fs.Dir reflects option files from it
+// and its parents, and subdirectories are processed relative to it. An error
+// with CodeBadConfig is returned if the path does not exist or is not a
+// directory.
+func parseBaseDir(cfg *mybase.Config) (*fs.Dir, error) {
+	dirPath := cfg.Get("dir")
+	fi, err := os.Stat(dirPath)
+	if err == nil && !fi.IsDir() {
+		return nil, NewExitValue(CodeBadConfig, "--dir=%s already exists but is not a directory", dirPath)
+	} else if os.IsNotExist(err) {
+		return nil, NewExitValue(CodeBadConfig, "--dir=%s does not exist", dirPath)
+	} else if err != nil {
+		return nil, err
+	}
+	return fs.ParseDir(dirPath, cfg)
+}
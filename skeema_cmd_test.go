@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strings"
@@ -187,14 +188,82 @@ func (s SkeemaIntegrationSuite) TestAddEnvHandler(t *testing.T) {
 }
 
 func (s SkeemaIntegrationSuite) TestPullHandler(t *testing.T) {
+	// Pull in a dir without any .skeema files should error
+	s.handleCommand(t, CodeBadConfig, ".", "skeema pull")
+
 	s.handleCommand(t, CodeSuccess, ".", "skeema init --dir mydb -h %s -P %d", s.d.Instance.Host, s.d.Instance.Port)
 
 	// In product db, alter one table and drop one table;
 	// In analytics db, add one table and alter the schema's charset and collation;
 	// Create a new db and put one table in it
 	s.sourceSQL(t, "pull1.sql")
-	cfg := s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+
+	// With --dry-run, no files should be modified, created, or deleted. The log
+	// output should describe each change that would be made.
+	var cfg *mybase.Config
+	output := captureLog(func() {
+		cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull --dry-run")
+	})
+	s.verifyFiles(t, cfg, "../golden/init")
+	expectOutput := []string{
+		"mydb/product/posts.sql would be updated -- table `posts` changed",
+		"mydb/product/comments.sql would be deleted -- table `comments` removed",
+		"mydb/analytics/widget_counts.sql requires addition of table `widget_counts`",
+	}
+	for _, expected := range expectOutput {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected output of pull --dry-run to contain %q, but it did not", expected)
+		}
+	}
+	// With --quiet, per-file informational messages should be suppressed, but
+	// the summary should still be logged
+	output = captureLog(func() {
+		cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull --dry-run --quiet")
+	})
+	s.verifyFiles(t, cfg, "../golden/init")
+	for _, unexpected := range []string{"Wrote ", "would be updated", "would be deleted", "requires addition", "Updating "} {
+		if strings.Contains(output, unexpected) {
+			t.Errorf("Expected output of pull --dry-run --quiet to not contain %q, but it did", unexpected)
+		}
+	}
+	if !strings.Contains(output, "Dry run complete, no files modified") {
+		t.Error("Expected output of pull --dry-run --quiet to contain summary, but it did not")
+	}
+	output = captureLog(func() {
+		cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull --dry-run --timing")
+	})
+	s.verifyFiles(t, cfg, "../golden/init")
+	if !strings.Contains(output, "Slowest 2 dir(s):") {
+		t.Error("Expected output of pull --timing to include timings for 2 dirs, but it did not")
+	}
+	for _, dirPath := range []string{"mydb/analytics", "mydb/product"} {
+		if !strings.Contains(output, dirPath+": ") {
+			t.Errorf("Expected output of pull --timing to include timing for %s, but it did not", dirPath)
+		}
+	}
+
+	// With --table, only that table's file should be updated. A table that
+	// doesn't exist in any schema should be an error.
+	s.handleCommand(t, CodeBadConfig, ".", "skeema pull --table=comments")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --table=posts")
+	if contents := fs.ReadTestFile(t, "mydb/product/posts.sql"); !strings.Contains(contents, "status") {
+		t.Error("Expected mydb/product/posts.sql to be updated by pull --table=posts, but it was not")
+	}
+	if _, err := os.Stat("mydb/product/comments.sql"); err != nil {
+		t.Errorf("Expected os.Stat to return nil error for mydb/product/comments.sql; instead err=%v", err)
+	}
+	if _, err := os.Stat("mydb/archives"); !os.IsNotExist(err) {
+		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/archives; instead err=%v", err)
+	}
+
+	// The summary should include the objects written to the new archives dir
+	output = captureLog(func() {
+		cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	})
 	s.verifyFiles(t, cfg, "../golden/pull1")
+	if expected := "Pull complete: objects added/updated/removed 2/0/1; schema dirs created/deleted 1/0"; !strings.Contains(output, expected) {
+		t.Errorf("Expected output of pull to contain summary %q, but it did not", expected)
+	}
 
 	// Revert db back to previous state, and pull again to test the opposite
 	// behaviors: delete dir for new schema, restore charset/collation in .skeema,
@@ -205,6 +274,36 @@ func (s SkeemaIntegrationSuite) TestPullHandler(t *testing.T) {
 	cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull")
 	s.verifyFiles(t, cfg, "../golden/init")
 
+	// Renaming a table should remove the file with the old name and write a file
+	// with the new name. Re-running pull after that should be a no-op. Swapping
+	// the names of two tables should rewrite both files to reflect the swap.
+	s.dbExec(t, "product", "RENAME TABLE posts TO articles")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	if _, err := os.Stat("mydb/product/posts.sql"); !os.IsNotExist(err) {
+		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/product/posts.sql; instead err=%v", err)
+	}
+	if contents := fs.ReadTestFile(t, "mydb/product/articles.sql"); !strings.Contains(contents, "CREATE TABLE `articles`") {
+		t.Errorf("Expected mydb/product/articles.sql to contain CREATE TABLE for renamed table; instead found contents:\n%s", contents)
+	}
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	if _, err := os.Stat("mydb/product/posts.sql"); !os.IsNotExist(err) {
+		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/product/posts.sql; instead err=%v", err)
+	}
+	s.dbExec(t, "product", "RENAME TABLE articles TO posts")
+	cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	s.verifyFiles(t, cfg, "../golden/init")
+	s.dbExec(t, "product", "RENAME TABLE users TO tmp_users, comments TO users, tmp_users TO comments")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	if contents := fs.ReadTestFile(t, "mydb/product/users.sql"); !strings.Contains(contents, "`post_id`") {
+		t.Errorf("Expected mydb/product/users.sql to reflect name swap, but it does not; contents:\n%s", contents)
+	}
+	if contents := fs.ReadTestFile(t, "mydb/product/comments.sql"); !strings.Contains(contents, "`credits`") {
+		t.Errorf("Expected mydb/product/comments.sql to reflect name swap, but it does not; contents:\n%s", contents)
+	}
+	s.dbExec(t, "product", "RENAME TABLE users TO tmp_users, comments TO users, tmp_users TO comments")
+	cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	s.verifyFiles(t, cfg, "../golden/init")
+
 	// Files with invalid SQL should still be corrected upon pull. Files with
 	// nonstandard formatting of their CREATE TABLE should be normalized, even if
 	// there was an ignored auto-increment change. Files with extraneous text
@@ -233,6 +332,32 @@ func (s SkeemaIntegrationSuite) TestPullHandler(t *testing.T) {
 		t.Error("Expected mydb/product/posts.sql to retain its extraneous comment, but it was removed")
 	}
 
+	// When a schema is dropped, pull should only delete the *.sql and .skeema
+	// files from its dir, keeping the dir if other files remain. With --force,
+	// the entire dir should be deleted.
+	s.sourceSQL(t, "pull1.sql")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	fs.WriteTestFile(t, "mydb/archives/README", "hello world")
+	s.cleanData(t, "setup.sql")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	if _, err := os.Stat("mydb/archives/README"); err != nil {
+		t.Errorf("Expected os.Stat to return nil error for mydb/archives/README; instead err=%v", err)
+	}
+	for _, name := range []string{"mydb/archives/.skeema", "mydb/archives/foo.sql"} {
+		if _, err := os.Stat(name); !os.IsNotExist(err) {
+			t.Errorf("Expected os.Stat to return IsNotExist error for %s; instead err=%v", name, err)
+		}
+	}
+	fs.RemoveTestDirectory(t, "mydb/archives")
+	s.sourceSQL(t, "pull1.sql")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	fs.WriteTestFile(t, "mydb/archives/README", "hello world")
+	s.cleanData(t, "setup.sql")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --force")
+	if _, err := os.Stat("mydb/archives"); !os.IsNotExist(err) {
+		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/archives; instead err=%v", err)
+	}
+
 	// Test behavior with --skip-new-schemas: new schema should not have a dir in
 	// fs, but changes to existing schemas should still be made
 	s.sourceSQL(t, "pull1.sql")
@@ -244,10 +369,108 @@ func (s SkeemaIntegrationSuite) TestPullHandler(t *testing.T) {
 		t.Errorf("Expected os.Stat to return nil error for mydb/analytics/widget_counts.sql; instead err=%v", err)
 	}
 
+	// Test --engines: tables using other storage engines should not get a file,
+	// and existing files for such tables should be left alone
+	s.dbExec(t, "product", "CREATE TABLE scratch_mem (id int) ENGINE=MEMORY")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --engines=innodb")
+	if _, err := os.Stat("mydb/product/scratch_mem.sql"); !os.IsNotExist(err) {
+		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/product/scratch_mem.sql; instead err=%v", err)
+	}
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	s.dbExec(t, "product", "ALTER TABLE scratch_mem ADD COLUMN name varchar(20)")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --engines=InnoDB,MyISAM")
+	if contents := fs.ReadTestFile(t, "mydb/product/scratch_mem.sql"); strings.Contains(contents, "name") {
+		t.Error("Expected mydb/product/scratch_mem.sql to be left alone by pull --engines, but it was updated")
+	}
+	s.dbExec(t, "product", "DROP TABLE scratch_mem")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+
+	// Test --changes: only the listed types of changes should be written, and
+	// invalid values should be rejected
+	s.handleCommand(t, CodeBadConfig, ".", "skeema pull --changes=create,rename")
+	s.dbExec(t, "product", "CREATE TABLE scratch_new (id int)")
+	s.dbExec(t, "product", "ALTER TABLE posts ADD COLUMN scratch_col int")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --changes=create")
+	if _, err := os.Stat("mydb/product/scratch_new.sql"); err != nil {
+		t.Errorf("Expected os.Stat to return nil error for mydb/product/scratch_new.sql; instead err=%v", err)
+	}
+	if contents := fs.ReadTestFile(t, "mydb/product/posts.sql"); strings.Contains(contents, "scratch_col") {
+		t.Error("Expected mydb/product/posts.sql to be left alone by pull --changes=create, but it was updated")
+	}
+	s.dbExec(t, "product", "DROP TABLE scratch_new")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --changes=alter")
+	if _, err := os.Stat("mydb/product/scratch_new.sql"); err != nil {
+		t.Errorf("Expected os.Stat to return nil error for mydb/product/scratch_new.sql; instead err=%v", err)
+	}
+	if contents := fs.ReadTestFile(t, "mydb/product/posts.sql"); !strings.Contains(contents, "scratch_col") {
+		t.Error("Expected mydb/product/posts.sql to be updated by pull --changes=alter, but it was not")
+	}
+	s.dbExec(t, "product", "ALTER TABLE posts DROP COLUMN scratch_col")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	if _, err := os.Stat("mydb/product/scratch_new.sql"); !os.IsNotExist(err) {
+		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/product/scratch_new.sql; instead err=%v", err)
+	}
+
+	// Updates to .skeema files for schema charset/collation or flavor are treated
+	// as alters by --changes
+	origSchema, err := s.d.Instance.Schema("product")
+	if err != nil {
+		t.Fatalf("Unexpected error from Schema: %s", err)
+	}
+	origProductOptions := fs.ReadTestFile(t, "mydb/product/.skeema")
+	origHostOptions := fs.ReadTestFile(t, "mydb/.skeema")
+	fs.WriteTestFile(t, "mydb/.skeema", strings.Replace(origHostOptions, "flavor", "#flavor", 1))
+	s.dbExec(t, "", "ALTER DATABASE product CHARACTER SET utf8 COLLATE utf8_swedish_ci")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --changes=create,drop")
+	if fs.ReadTestFile(t, "mydb/product/.skeema") != origProductOptions {
+		t.Error("Expected mydb/product/.skeema to be left alone by pull --changes=create,drop, but it was updated")
+	}
+	if strings.Contains(fs.ReadTestFile(t, "mydb/.skeema"), "\nflavor") {
+		t.Error("Expected mydb/.skeema to be left alone by pull --changes=create,drop, but it was updated")
+	}
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --changes=alter")
+	if !strings.Contains(fs.ReadTestFile(t, "mydb/product/.skeema"), "utf8_swedish_ci") {
+		t.Error("Expected mydb/product/.skeema to be updated by pull --changes=alter, but it was not")
+	}
+	if !strings.Contains(fs.ReadTestFile(t, "mydb/.skeema"), "\nflavor") {
+		t.Error("Expected mydb/.skeema to be updated by pull --changes=alter, but it was not")
+	}
+	s.dbExec(t, "", fmt.Sprintf("ALTER DATABASE product CHARACTER SET %s COLLATE %s", origSchema.CharSet, origSchema.Collation))
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+
+	// A file whose name doesn't match its table should result in a warning from
+	// pull, but not from other commands
+	if err := os.Rename("mydb/product/posts.sql", "mydb/product/articles.sql"); err != nil {
+		t.Fatalf("Unable to rename file: %s", err)
+	}
+	output = captureLog(func() {
+		s.handleCommand(t, CodeSuccess, ".", "skeema diff")
+	})
+	if strings.Contains(output, "consider renaming") {
+		t.Error("Expected diff to not warn about file name mismatch, but it did")
+	}
+	output = captureLog(func() {
+		s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	})
+	if !strings.Contains(output, "articles.sql: file name does not match name of table `posts` created in the file; consider renaming the file to posts.sql") {
+		t.Error("Expected pull to warn about file name mismatch, but it did not")
+	}
+	if err := os.Rename("mydb/product/articles.sql", "mydb/product/posts.sql"); err != nil {
+		t.Fatalf("Unable to rename file: %s", err)
+	}
+
 	// If a dir has a bad option file, new schema detection should also be skipped,
 	// since we don't know what schemas the bad subdir maps to
 	fs.WriteTestFile(t, "mydb/analytics/.skeema", "this won't parse anymore")
+	fs.RemoveTestFile(t, "mydb/product/users.sql")
+	s.handleCommand(t, CodeFatalError, ".", "skeema pull --preflight")
+	if _, err := os.Stat("mydb/product/users.sql"); !os.IsNotExist(err) {
+		t.Errorf("Expected pull --preflight to abort without modifying files, but os.Stat returned %v for mydb/product/users.sql", err)
+	}
 	s.handleCommand(t, CodePartialError, ".", "skeema pull")
+	if _, err := os.Stat("mydb/product/users.sql"); err != nil {
+		t.Errorf("Expected os.Stat to return nil error for mydb/product/users.sql; instead err=%v", err)
+	}
 	if _, err := os.Stat("mydb/archives"); !os.IsNotExist(err) {
 		t.Errorf("Expected os.Stat to return IsNotExist error for mydb/archives; instead err=%v", err)
 	}
@@ -277,6 +500,12 @@ func (s SkeemaIntegrationSuite) TestLintHandler(t *testing.T) {
 	s.handleCommand(t, CodeBadConfig, ".", "skeema lint --workspace=doesnt-exist")
 	s.handleCommand(t, CodeBadConfig, "mydb/product", "skeema lint --password=wrong")
 
+	// --dir should behave the same as running from that directory, and should
+	// error if the path does not exist or is not a directory
+	s.handleCommand(t, CodeSuccess, ".", "skeema lint --dir mydb/product")
+	s.handleCommand(t, CodeBadConfig, ".", "skeema lint --dir mydb/doesnt-exist")
+	s.handleCommand(t, CodeBadConfig, ".", "skeema lint --dir mydb/.skeema")
+
 	// Alter a few files in a way that is still valid SQL, but doesn't match
 	// the database's native format. Lint with --skip-format should do nothing;
 	// otherwise lint with default of format should rewrite these files and then
@@ -376,7 +605,12 @@ func (s SkeemaIntegrationSuite) TestFormatHandler(t *testing.T) {
 		}
 	}
 	rewriteFiles(false)
-	s.handleCommand(t, CodeDifferencesFound, ".", "skeema format --skip-write")
+	output := captureLog(func() {
+		s.handleCommand(t, CodeDifferencesFound, ".", "skeema format --skip-write")
+	})
+	if !strings.Contains(output, "requires formatting changes") || strings.Contains(output, "would be updated") {
+		t.Errorf("Unexpected output from format --skip-write: %s", output)
+	}
 	s.handleCommand(t, CodeDifferencesFound, ".", "skeema format --skip-write")
 	s.handleCommand(t, CodeDifferencesFound, ".", "skeema format")
 	s.handleCommand(t, CodeSuccess, ".", "skeema format")
@@ -444,6 +678,83 @@ func (s SkeemaIntegrationSuite) TestDiffHandler(t *testing.T) {
 	}
 }
 
+func (s SkeemaIntegrationSuite) TestExportHandler(t *testing.T) {
+	s.handleCommand(t, CodeSuccess, ".", "skeema init --dir mydb -h %s -P %d", s.d.Instance.Host, s.d.Instance.Port)
+	s.handleCommand(t, CodeBadConfig, ".", "skeema export --dir mydb/doesnt-exist")
+
+	// Output should be valid JSON containing each schema, and should omit tables
+	// matching ignore-table
+	oldStdout := os.Stdout
+	outFile, err := os.Create("export.out")
+	if err != nil {
+		t.Fatalf("Unable to redirect stdout to a file: %s", err)
+	}
+	os.Stdout = outFile
+	s.handleCommand(t, CodeSuccess, ".", "skeema export --ignore-table=^comments$")
+	outFile.Close()
+	os.Stdout = oldStdout
+	output := fs.ReadTestFile(t, "export.out")
+	fs.RemoveTestFile(t, "export.out")
+	var exported []exportedSchema
+	if err := json.Unmarshal([]byte(output), &exported); err != nil {
+		t.Fatalf("Unable to parse output of `skeema export` as JSON: %s", err)
+	}
+	foundTables := make(map[string]exportedTable)
+	for _, es := range exported {
+		if es.Instance != s.d.Instance.String() {
+			t.Errorf("Expected instance %s, instead found %s", s.d.Instance, es.Instance)
+		}
+		for _, table := range es.Tables {
+			foundTables[es.Name+"."+table.Name] = table
+			if len(table.Columns) == 0 {
+				t.Errorf("Expected table %s.%s to have columns in export, but it did not", es.Name, table.Name)
+			}
+		}
+	}
+	if len(exported) != 2 {
+		t.Errorf("Expected 2 schemas in export, instead found %d", len(exported))
+	}
+	users, ok := foundTables["product.users"]
+	if _, hasComments := foundTables["product.comments"]; !ok || hasComments {
+		t.Fatalf("Unexpected set of tables in export: %v", foundTables)
+	}
+
+	// Confirm the columns and indexes of one table in detail
+	var colNames []string
+	for _, col := range users.Columns {
+		colNames = append(colNames, col.Name)
+	}
+	if strings.Join(colNames, ",") != "id,name,credits,last_modified" {
+		t.Errorf("Unexpected columns in export of product.users: %v", colNames)
+	}
+	if id := users.Columns[0]; id.Nullable || !id.AutoIncrement || !strings.HasPrefix(id.Type, "bigint") {
+		t.Errorf("Unexpected export of column product.users.id: %+v", id)
+	}
+	if credits := users.Columns[2]; !credits.Nullable || credits.Default != "'10.00'" {
+		t.Errorf("Unexpected export of column product.users.credits: %+v", credits)
+	}
+	if len(users.Indexes) != 2 {
+		t.Fatalf("Expected 2 indexes in export of product.users, instead found %+v", users.Indexes)
+	}
+	if pk := users.Indexes[0]; !pk.Primary || len(pk.Parts) != 1 || pk.Parts[0].Column != "id" {
+		t.Errorf("Unexpected export of primary key of product.users: %+v", pk)
+	}
+	if idx := users.Indexes[1]; idx.Name != "name" || idx.Primary || !idx.Unique || len(idx.Parts) != 1 || idx.Parts[0].Column != "name" {
+		t.Errorf("Unexpected export of index product.users.name: %+v", idx)
+	}
+
+	// tengo-internal fields should not be part of the output format
+	for _, internalField := range []string{"showCreateTable", "unsupportedForDiff", "nextAutoIncrement"} {
+		if strings.Contains(output, internalField) {
+			t.Errorf("Expected output of `skeema export` to not contain %q, but it did", internalField)
+		}
+	}
+
+	// A schema that no longer exists should be skipped
+	s.dbExec(t, "", "DROP DATABASE analytics")
+	s.handleCommand(t, CodePartialError, ".", "skeema export")
+}
+
 func (s SkeemaIntegrationSuite) TestPushHandler(t *testing.T) {
 	s.handleCommand(t, CodeSuccess, ".", "skeema init --dir mydb -h %s -P %d", s.d.Instance.Host, s.d.Instance.Port)
 
@@ -1122,6 +1433,134 @@ func (s SkeemaIntegrationSuite) TestShardedSchemas(t *testing.T) {
 	s.handleCommand(t, CodeFatalError, ".", "skeema push")
 }
 
+func (s SkeemaIntegrationSuite) TestPullCheckDrift(t *testing.T) {
+	s.handleCommand(t, CodeSuccess, ".", "skeema init --dir mydb -h %s -P %d", s.d.Instance.Host, s.d.Instance.Port)
+	contents := fs.ReadTestFile(t, "mydb/product/.skeema")
+	contents = strings.Replace(contents, "schema=product", "schema=product,product2,product3", 1)
+	fs.WriteTestFile(t, "mydb/product/.skeema", contents)
+	s.handleCommand(t, CodeSuccess, ".", "skeema push")
+
+	// No drift yet, even with check-drift enabled
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull --check-drift")
+
+	// Drift is only reported if check-drift is enabled, and changes the exit code
+	s.dbExec(t, "product2", "ALTER TABLE comments ADD COLUMN `approved` tinyint(1) NOT NULL")
+	s.dbExec(t, "product3", "CREATE TABLE `foo` (id int)")
+	s.dbExec(t, "product3", "ALTER TABLE posts AUTO_INCREMENT=1000")
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	s.handleCommand(t, CodeDifferencesFound, ".", "skeema pull --check-drift")
+	cfg := s.handleCommand(t, CodeDifferencesFound, ".", "skeema pull --check-drift --ignore-table=^foo$")
+
+	// Confirm the reported drift keys. Next auto-increment values, and tables
+	// matching ignore-table, should not be included.
+	dir, err := fs.ParseDir("mydb/product", cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDir: %s", err)
+	}
+	instSchema, err := s.d.Instance.Schema("product")
+	if err != nil {
+		t.Fatalf("Unexpected error from Schema: %s", err)
+	}
+	drift, err := reportSchemaDrift(dir, s.d.Instance, instSchema, []string{"product2", "product3"})
+	if err != nil {
+		t.Fatalf("Unexpected error from reportSchemaDrift: %s", err)
+	}
+	if len(drift) != 1 || len(drift["product2"]) != 1 || drift["product2"][0] != "table `comments`" {
+		t.Errorf("Unexpected result from reportSchemaDrift: %v", drift)
+	}
+	cfg = s.handleCommand(t, CodeDifferencesFound, ".", "skeema pull --check-drift")
+	if dir, err = fs.ParseDir("mydb/product", cfg); err != nil {
+		t.Fatalf("Unexpected error from ParseDir: %s", err)
+	}
+	if drift, err = reportSchemaDrift(dir, s.d.Instance, instSchema, []string{"product2", "product3"}); err != nil {
+		t.Fatalf("Unexpected error from reportSchemaDrift: %s", err)
+	}
+	if len(drift) != 2 || len(drift["product3"]) != 1 || drift["product3"][0] != "table `foo`" {
+		t.Errorf("Unexpected result from reportSchemaDrift: %v", drift)
+	}
+
+	// When some dirs are also skipped due to errors, the exit code is the same,
+	// but the drift should still be reported alongside the skip count
+	fs.WriteTestFile(t, "mydb/analytics/.skeema", "this won't parse anymore")
+	output := captureLog(func() {
+		s.handleCommand(t, CodePartialError, ".", "skeema pull --check-drift")
+	})
+	if !strings.Contains(output, "Skipped 1 operation due to error. Found drift in 2 schema(s)") {
+		t.Errorf("Expected pull output to report both skips and drift, but it did not: %s", output)
+	}
+}
+
+func (s SkeemaIntegrationSuite) TestPullCheckDriftHosts(t *testing.T) {
+	cfg := s.handleCommand(t, CodeSuccess, ".", "skeema init --dir mydb -h %s -P %d", s.d.Instance.Host, s.d.Instance.Port)
+
+	// Map the host dir to two addresses for the same server, plus an unreachable
+	// address. No drift should be found, and the unreachable host should not
+	// cause an error.
+	file := getOptionFile(t, "mydb", cfg)
+	hosts := fmt.Sprintf("%s:%d,localhost:%d,127.0.0.1:1", s.d.Instance.Host, s.d.Instance.Port, s.d.Instance.Port)
+	file.SetOptionValue("", "host", hosts)
+	file.UnsetOptionValue("", "port")
+	if err := file.Write(true); err != nil {
+		t.Fatalf("Unable to write %s: %s", file.Path(), err)
+	}
+	s.handleCommand(t, CodeSuccess, ".", "skeema pull")
+	cfg = s.handleCommand(t, CodeSuccess, ".", "skeema pull --check-drift")
+
+	// Only the reachable other host should be returned
+	dir, err := fs.ParseDir("mydb", cfg)
+	if err != nil {
+		t.Fatalf("Unexpected error from ParseDir: %s", err)
+	}
+	instance, err := dir.FirstInstance()
+	if err != nil {
+		t.Fatalf("Unexpected error from FirstInstance: %s", err)
+	}
+	others := reachableOtherInstances(dir, instance)
+	if len(others) != 1 || others[0].String() != fmt.Sprintf("localhost:%d", s.d.Instance.Port) {
+		t.Fatalf("Unexpected result from reachableOtherInstances: %v", others)
+	}
+
+	// Simulate drift by comparing against a modified copy of the schema: the
+	// other host will appear to have an extra table and a modified table.
+	// A schema missing entirely from the other host also counts as drift.
+	if dir, err = fs.ParseDir("mydb/product", cfg); err != nil {
+		t.Fatalf("Unexpected error from ParseDir: %s", err)
+	}
+	instSchema, err := instance.Schema("product")
+	if err != nil {
+		t.Fatalf("Unexpected error from Schema: %s", err)
+	}
+	if drifted, err := reportInstanceDrift(dir, instance, others, instSchema); err != nil || len(drifted) != 0 {
+		t.Errorf("Unexpected result from reportInstanceDrift: %v, %v", drifted, err)
+	}
+	modified := *instSchema
+	modified.Tables = nil
+	for _, table := range instSchema.Tables {
+		if table.Name == "posts" {
+			continue
+		}
+		modified.Tables = append(modified.Tables, table)
+	}
+	if drifted, err := reportInstanceDrift(dir, instance, others, &modified); err != nil || len(drifted) != 1 || drifted[0] != others[0].String() {
+		t.Errorf("Unexpected result from reportInstanceDrift: %v, %v", drifted, err)
+	}
+	mods, err := statementModifiersForDrift(dir, instance)
+	if err != nil {
+		t.Fatalf("Unexpected error from statementModifiersForDrift: %s", err)
+	}
+	otherSchema, err := others[0].Schema("product")
+	if err != nil {
+		t.Fatalf("Unexpected error from Schema: %s", err)
+	}
+	if driftKeys := schemaDriftKeys(&modified, otherSchema, mods); len(driftKeys) != 1 || driftKeys[0] != "table `posts`" {
+		t.Errorf("Unexpected result from schemaDriftKeys: %v", driftKeys)
+	}
+	modified.Name = "doesnt_exist"
+	if drifted, err := reportInstanceDrift(dir, instance, others, &modified); err != nil || len(drifted) != 1 {
+		t.Errorf("Unexpected result from reportInstanceDrift: %v, %v", drifted, err)
+	}
+}
+
 func (s SkeemaIntegrationSuite) TestFlavorConfig(t *testing.T) {
 	// Set up dir mydb to have flavor set, and then remove the flavor from
 	// the cached Instance, so that we can test the ability of the flavor option
@@ -1,9 +1,10 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
-	log "github.com/sirupsen/logrus"
+	"io"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"testing"
 
+	log "github.com/sirupsen/logrus"
 	"github.com/skeema/mybase"
 	"github.com/skeema/skeema/fs"
 	"github.com/skeema/skeema/util"
@@ -133,8 +135,10 @@ func (s *SkeemaIntegrationSuite) handleCommand(t *testing.T, expectedExitCode in
 	fmt.Fprintf(os.Stderr, "\x1b[37;1m%s$\x1b[0m %s\n", filepath.Join("testdata", ".scratch", pwd), fullCommandLine)
 	fakeFileSource := mybase.SimpleSource(map[string]string{"password": s.d.Instance.Password})
 	cfg := mybase.ParseFakeCLI(t, CommandSuite, fullCommandLine, fakeFileSource)
-	util.AddGlobalConfigFiles(cfg)
-	err := util.ProcessSpecialGlobalOptions(cfg)
+	err := util.AddGlobalConfigFiles(cfg)
+	if err == nil {
+		err = util.ProcessSpecialGlobalOptions(cfg)
+	}
 	if err != nil {
 		err = NewExitValue(CodeBadConfig, err.Error())
 	} else {
@@ -173,6 +177,17 @@ func (s *SkeemaIntegrationSuite) handleCommand(t *testing.T, expectedExitCode in
 	return cfg
 }
 
+// captureLog runs f and returns the log output it generated. The output is
+// still written to the log's usual destination as well.
+func captureLog(f func()) string {
+	var buf bytes.Buffer
+	origOut := log.StandardLogger().Out
+	log.SetOutput(io.MultiWriter(origOut, &buf))
+	defer log.SetOutput(origOut)
+	f()
+	return buf.String()
+}
+
 // verifyFiles compares the files in testdata/.scratch to the files in the
 // specified dir, and fails the test if any differences are found.
 func (s *SkeemaIntegrationSuite) verifyFiles(t *testing.T, cfg *mybase.Config, dirExpectedBase string) {
@@ -3,6 +3,7 @@ package util
 import (
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -34,20 +35,34 @@ func AddGlobalOptions(cmd *mybase.Command) {
 	// Visible global options
 	cmd.AddOption(mybase.StringOption("user", 'u', "root", "Username to connect to database host"))
 	cmd.AddOption(mybase.StringOption("password", 'p', "", "Password for database user; omit value to prompt from TTY (default no password)").ValueOptional())
+	cmd.AddOption(mybase.StringOption("password-file", 0, "", "Path to a file whose first line is the password for database user"))
 	cmd.AddOption(mybase.StringOption("host-wrapper", 'H', "", "External bin to shell out to for host lookup; see manual for template vars"))
 	cmd.AddOption(mybase.StringOption("temp-schema", 't', "_skeema_tmp", "Name of temporary schema for intermediate operations, created and dropped each run"))
 	cmd.AddOption(mybase.StringOption("temp-schema-binlog", 0, "auto", `Controls whether temp schema DDL operations are replicated (valid values: "on", "off", "auto")`))
 	cmd.AddOption(mybase.StringOption("temp-schema-threads", 0, "5", "Max number of concurrent CREATE/DROP with workspace=temp-schema"))
 	cmd.AddOption(mybase.StringOption("connect-options", 'o', "", "Comma-separated session options to set upon connecting to each database instance"))
+	cmd.AddOption(mybase.StringOption("ssl-mode", 0, "disabled", `Controls use of TLS for database connections (valid values: "disabled", "preferred", "required", "verify-ca", "verify-identity")`))
+	cmd.AddOption(mybase.StringOption("ssl-ca", 0, "", "Path to PEM file of trusted certificate authorities, for verifying database server certificates"))
+	cmd.AddOption(mybase.StringOption("ssl-cert", 0, "", "Path to PEM file of client certificate, for TLS connections to database"))
+	cmd.AddOption(mybase.StringOption("ssl-key", 0, "", "Path to PEM file of client private key, for TLS connections to database"))
 	cmd.AddOption(mybase.StringOption("workspace", 'w', "temp-schema", `Specifies where to run intermediate operations (valid values: "temp-schema", "docker")`))
 	cmd.AddOption(mybase.StringOption("docker-cleanup", 0, "none", `With --workspace=docker, specifies how to clean up containers (valid values: "none", "stop", "destroy")`))
 	cmd.AddOption(mybase.BoolOption("debug", 0, false, "Enable debug logging"))
 	cmd.AddOption(mybase.BoolOption("my-cnf", 0, true, "Parse ~/.my.cnf for configuration"))
+	cmd.AddOption(mybase.StringOption("config", 0, "", "Path to an additional global option file, applied after the standard ones"))
 }
 
 // AddGlobalConfigFiles takes the mybase.Config generated from the CLI and adds
-// global option files as sources.
-func AddGlobalConfigFiles(cfg *mybase.Config) {
+// global option files as sources. Problems with the standard global option
+// files are logged as warnings, but a file explicitly requested via the config
+// option must be readable and valid, or an error is returned.
+func AddGlobalConfigFiles(cfg *mybase.Config) error {
+	// Environment variables have the lowest priority of any source other than
+	// option defaults, so they're added before any files
+	if source := envOptionSource(); len(source) > 0 {
+		cfg.AddSource(source)
+	}
+
 	globalFilePaths := make([]string, 0, 4)
 
 	// Avoid using "real" global paths in test logic. Otherwise, if the user
@@ -91,6 +106,55 @@ func AddGlobalConfigFiles(cfg *mybase.Config) {
 
 		cfg.AddSource(f)
 	}
+
+	if explicitPath := cfg.Get("config"); explicitPath != "" {
+		f := mybase.NewFile(explicitPath)
+		if err := f.Read(); err != nil {
+			return fmt.Errorf("Unable to read option file %s specified by --config: %s", explicitPath, err)
+		}
+		if err := f.Parse(cfg); err != nil {
+			return fmt.Errorf("Unable to parse option file %s specified by --config: %s", explicitPath, err)
+		}
+		if cfg.CLI.Command.HasArg("environment") {
+			_ = f.UseSection(cfg.Get("environment")) // safe to ignore error (doesn't matter if section doesn't exist)
+		}
+		cfg.AddSource(f)
+	}
+	return nil
+}
+
+// envOptionNames maps environment variable names to the options they supply.
+// host is intentionally excluded, since it may only be set in per-directory
+// option files for most commands.
+var envOptionNames = map[string]string{
+	"SKEEMA_USER":     "user",
+	"SKEEMA_PASSWORD": "password",
+	"SKEEMA_PORT":     "port",
+}
+
+// envSource is an option source backed by environment variables.
+type envSource map[string]string
+
+// OptionValue satisfies the mybase.OptionValuer interface.
+func (source envSource) OptionValue(optionName string) (string, bool) {
+	val, ok := source[optionName]
+	return val, ok
+}
+
+func (source envSource) String() string {
+	return "environment variables"
+}
+
+// envOptionSource returns an envSource containing option values from any
+// non-empty environment variables in envOptionNames.
+func envOptionSource() envSource {
+	source := make(envSource)
+	for envName, optionName := range envOptionNames {
+		if val := os.Getenv(envName); val != "" {
+			source[optionName] = val
+		}
+	}
+	return source
 }
 
 // ProcessSpecialGlobalOptions performs special handling of global options with
@@ -109,11 +173,19 @@ func ProcessSpecialGlobalOptions(cfg *mybase.Config) error {
 		}
 	}
 
-	// Special handling for password option: if not supplied at all, check env
-	// var instead. Or if supplied but with no equals sign or value, prompt on
-	// STDIN like mysql client does.
+	// Special handling for password option: if not supplied at all, check
+	// password-file and then env var instead. Or if supplied but with no equals
+	// sign or value, prompt on STDIN like mysql client does.
 	if !cfg.Supplied("password") {
-		if val := os.Getenv("MYSQL_PWD"); val != "" {
+		if passwordFile := cfg.Get("password-file"); passwordFile != "" {
+			val, err := ReadPasswordFile(passwordFile)
+			if err != nil {
+				return err
+			}
+			// Added as a source, rather than as a CLI value, so that a password in a
+			// per-directory .skeema file (added as a source later) still overrides it
+			cfg.AddSource(passwordFileSource{path: passwordFile, password: val})
+		} else if val := os.Getenv("MYSQL_PWD"); val != "" {
 			cfg.CLI.OptionValues["password"] = val
 			cfg.MarkDirty()
 		}
@@ -149,6 +221,41 @@ func PromptPassword() (string, error) {
 	return string(bytePassword), nil
 }
 
+// passwordFileSource is an option source supplying the password option from
+// the contents of the file specified by the password-file option.
+type passwordFileSource struct {
+	path     string
+	password string
+}
+
+// OptionValue satisfies the mybase.OptionValuer interface.
+func (source passwordFileSource) OptionValue(optionName string) (string, bool) {
+	if optionName == "password" {
+		return source.password, true
+	}
+	return "", false
+}
+
+func (source passwordFileSource) String() string {
+	return "password-file " + source.path
+}
+
+// ReadPasswordFile returns the first line of the file at filePath, without
+// any trailing newline. An error is returned if the file cannot be read, or if
+// its first line is empty.
+func ReadPasswordFile(filePath string) (string, error) {
+	contents, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return "", fmt.Errorf("Unable to read password-file: %s", err)
+	}
+	password := strings.SplitN(string(contents), "\n", 2)[0]
+	password = strings.TrimSuffix(password, "\r")
+	if password == "" {
+		return "", fmt.Errorf("Unable to use password-file %s: first line of file is empty", filePath)
+	}
+	return password, nil
+}
+
 // SplitConnectOptions takes a string containing a comma-separated list of
 // connection options (typically obtained from the "connect-options" option)
 // and splits them into a map of individual key: value strings. This function
@@ -3,6 +3,7 @@ package util
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
@@ -68,6 +69,95 @@ func TestAddGlobalConfigFiles(t *testing.T) {
 	if cfg.Supplied("password") || cfg.Changed("password") {
 		t.Errorf("Expected password to be unsupplied and unchanged from default; instead found %q", cfg.GetRaw("password"))
 	}
+
+	// Test --config: the explicit file should override the standard global files,
+	// using the section for the selected environment. A missing or invalid file
+	// should be an error.
+	ioutil.WriteFile("fake-etc/explicit", []byte("user=three\n[staging]\nuser=four\n"), 0777)
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --config=fake-etc/explicit")
+	if err := AddGlobalConfigFiles(cfg); err != nil {
+		t.Errorf("Unexpected error from AddGlobalConfigFiles: %s", err)
+	} else if actualUser := cfg.Get("user"); actualUser != "three" {
+		t.Errorf("Expected user in fake-etc/explicit to take precedence; instead found %s", actualUser)
+	}
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --config=fake-etc/explicit staging")
+	if err := AddGlobalConfigFiles(cfg); err != nil {
+		t.Errorf("Unexpected error from AddGlobalConfigFiles: %s", err)
+	} else if actualUser := cfg.Get("user"); actualUser != "four" {
+		t.Errorf("Expected user in staging section of fake-etc/explicit to take precedence; instead found %s", actualUser)
+	}
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --config=fake-etc/doesnt-exist")
+	if err := AddGlobalConfigFiles(cfg); err == nil {
+		t.Error("Expected error from AddGlobalConfigFiles with nonexistent --config, but err was nil")
+	}
+	ioutil.WriteFile("fake-etc/explicit", []byte("this will not parse\n"), 0777)
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --config=fake-etc/explicit")
+	if err := AddGlobalConfigFiles(cfg); err == nil {
+		t.Error("Expected error from AddGlobalConfigFiles with invalid --config, but err was nil")
+	}
+
+	// Like other global option files, the explicit file may not set host or
+	// schema. Expectation: the file is still applied as a source, but
+	// ProcessSpecialGlobalOptions rejects it.
+	for _, contents := range []string{"host=uhoh\n", "[staging]\nschema=uhoh\n"} {
+		ioutil.WriteFile("fake-etc/explicit", []byte(contents), 0777)
+		cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --config=fake-etc/explicit staging")
+		if err := AddGlobalConfigFiles(cfg); err != nil {
+			t.Errorf("Unexpected error from AddGlobalConfigFiles: %s", err)
+		} else if err := ProcessSpecialGlobalOptions(cfg); err == nil {
+			t.Errorf("Expected error from ProcessSpecialGlobalOptions with --config file containing %q, but err was nil", contents)
+		} else if !strings.Contains(err.Error(), "fake-etc/explicit") {
+			t.Errorf("Expected error to mention fake-etc/explicit, instead found: %s", err)
+		}
+	}
+}
+
+func TestAddGlobalConfigFilesEnv(t *testing.T) {
+	cmdSuite := mybase.NewCommandSuite("skeematest", "", "")
+	AddGlobalOptions(cmdSuite)
+	cmd := mybase.NewCommand("diff", "", "", nil)
+	cmd.AddArg("environment", "production", false)
+	cmdSuite.AddSubCommand(cmd)
+
+	os.Setenv("SKEEMA_USER", "envuser")
+	os.Setenv("SKEEMA_PORT", "3307")
+	defer func() {
+		os.Unsetenv("SKEEMA_USER")
+		os.Unsetenv("SKEEMA_PORT")
+	}()
+
+	// Expectation: env vars used if nothing else supplies the options
+	cfg := mybase.ParseFakeCLI(t, cmdSuite, "skeema diff")
+	if err := AddGlobalConfigFiles(cfg); err != nil {
+		t.Fatalf("Unexpected error from AddGlobalConfigFiles: %s", err)
+	}
+	if actualUser := cfg.Get("user"); actualUser != "envuser" {
+		t.Errorf("Expected user to come from SKEEMA_USER; instead found %s", actualUser)
+	}
+	if actualPort := cfg.GetIntOrDefault("port"); actualPort != 3307 {
+		t.Errorf("Expected port to come from SKEEMA_PORT; instead found %d", actualPort)
+	}
+	if cfg.Changed("password") {
+		t.Errorf("Expected password to be unchanged from default; instead found %q", cfg.GetRaw("password"))
+	}
+
+	// Expectation: option files and the CLI override env vars
+	os.MkdirAll("fake-etc", 0777)
+	ioutil.WriteFile("fake-etc/skeema", []byte("user=fileuser\n"), 0777)
+	defer os.RemoveAll("fake-etc")
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff")
+	AddGlobalConfigFiles(cfg)
+	if actualUser := cfg.Get("user"); actualUser != "fileuser" {
+		t.Errorf("Expected user in fake-etc/skeema to take precedence; instead found %s", actualUser)
+	}
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --user=cliuser --port=3308")
+	AddGlobalConfigFiles(cfg)
+	if actualUser := cfg.Get("user"); actualUser != "cliuser" {
+		t.Errorf("Expected user on CLI to take precedence; instead found %s", actualUser)
+	}
+	if actualPort := cfg.GetIntOrDefault("port"); actualPort != 3308 {
+		t.Errorf("Expected port on CLI to take precedence; instead found %d", actualPort)
+	}
 }
 
 func TestPasswordOption(t *testing.T) {
@@ -127,13 +217,46 @@ func TestPasswordOption(t *testing.T) {
 	}
 	assertPassword(cfg, "howdyplanet")
 
+	// Password set via password-file and env: password-file should win out, but
+	// the password option should still take precedence over it. A missing or
+	// empty password-file should be an error.
+	dir, err := ioutil.TempDir("", "skeemapw")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	pwPath := filepath.Join(dir, "pw")
+	if err := ioutil.WriteFile(pwPath, []byte("secretsauce\nignored line\n"), 0600); err != nil {
+		t.Fatalf("Unable to write %s: %s", pwPath, err)
+	}
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --password-file="+pwPath)
+	if err := ProcessSpecialGlobalOptions(cfg); err != nil {
+		t.Errorf("Unexpected error from ProcessSpecialGlobalOptions: %s", err)
+	}
+	assertPassword(cfg, "secretsauce")
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --password-file="+pwPath, fakeFileSource)
+	if err := ProcessSpecialGlobalOptions(cfg); err != nil {
+		t.Errorf("Unexpected error from ProcessSpecialGlobalOptions: %s", err)
+	}
+	assertPassword(cfg, "howdyplanet")
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --password-file="+filepath.Join(dir, "doesnt-exist"))
+	if err := ProcessSpecialGlobalOptions(cfg); err == nil {
+		t.Error("Expected ProcessSpecialGlobalOptions to return an error for nonexistent password-file, but it did not")
+	}
+	if err := ioutil.WriteFile(pwPath, []byte("\n"), 0600); err != nil {
+		t.Fatalf("Unable to write %s: %s", pwPath, err)
+	}
+	cfg = mybase.ParseFakeCLI(t, cmdSuite, "skeema diff --password-file="+pwPath)
+	if err := ProcessSpecialGlobalOptions(cfg); err == nil {
+		t.Error("Expected ProcessSpecialGlobalOptions to return an error for empty password-file, but it did not")
+	}
+
 	// ProcessSpecialGlobalOptions should error with valueless password if STDIN
 	// isn't a TTY. Test bare "password" (no =) on both CLI and config file.
 	oldStdin := os.Stdin
 	defer func() {
 		os.Stdin = oldStdin
 	}()
-	var err error
 	if os.Stdin, err = os.Open("../testdata/setup.sql"); err != nil {
 		t.Fatalf("Unable to open ../testdata/setup.sql: %s", err)
 	}
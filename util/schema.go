@@ -0,0 +1,18 @@
+package util
+
+import "strings"
+
+// systemSchemas lists schemas that are internal to the database server. Skeema
+// never manages these, regardless of configuration.
+var systemSchemas = map[string]bool{
+	"information_schema": true,
+	"performance_schema": true,
+	"sys":                true,
+	"mysql":              true,
+}
+
+// IsSystemSchema returns true if name refers to a system schema, which Skeema
+// should never operate on. The comparison is case-insensitive.
+func IsSystemSchema(name string) bool {
+	return systemSchemas[strings.ToLower(name)]
+}
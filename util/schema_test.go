@@ -0,0 +1,20 @@
+package util
+
+import "testing"
+
+func TestIsSystemSchema(t *testing.T) {
+	expected := map[string]bool{
+		"mysql":              true,
+		"information_schema": true,
+		"PERFORMANCE_SCHEMA": true,
+		"Sys":                true,
+		"product":            false,
+		"mysqlx":             false,
+		"":                   false,
+	}
+	for name, expect := range expected {
+		if actual := IsSystemSchema(name); actual != expect {
+			t.Errorf("Expected IsSystemSchema(%q) to return %t, instead found %t", name, expect, actual)
+		}
+	}
+}
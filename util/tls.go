@@ -0,0 +1,135 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+var tlsConfigCache struct {
+	sync.Mutex
+	nameMap map[string]string
+}
+
+func init() {
+	tlsConfigCache.nameMap = make(map[string]string)
+}
+
+// RegisterTLSConfig interprets the supplied ssl-mode, ssl-ca, ssl-cert, and
+// ssl-key option values, and returns a value suitable for use in the "tls" DSN
+// param of go-sql-driver/mysql. If a custom tls.Config is needed, it will be
+// registered with the driver. An empty string is returned if TLS should not be
+// used at all. Identical requests will reuse the same registered tls.Config.
+func RegisterTLSConfig(sslMode, caPath, certPath, keyPath string) (string, error) {
+	sslMode = strings.ToLower(sslMode)
+	switch sslMode {
+	case "", "disabled":
+		return "", nil
+	case "preferred":
+		if caPath != "" || certPath != "" || keyPath != "" {
+			return "", errors.New("ssl-ca, ssl-cert, and ssl-key require ssl-mode to be one of required, verify-ca, or verify-identity")
+		}
+		return "preferred", nil
+	case "required", "verify-ca", "verify-identity":
+		// handled below
+	default:
+		return "", fmt.Errorf("Invalid value for ssl-mode: %q (valid values: disabled, preferred, required, verify-ca, verify-identity)", sslMode)
+	}
+
+	key := strings.Join([]string{sslMode, caPath, certPath, keyPath}, "\000")
+	tlsConfigCache.Lock()
+	defer tlsConfigCache.Unlock()
+	if name, already := tlsConfigCache.nameMap[key]; already {
+		return name, nil
+	}
+
+	config, err := newTLSConfig(sslMode, caPath, certPath, keyPath)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("skeema-%d", len(tlsConfigCache.nameMap)+1)
+	if err := mysql.RegisterTLSConfig(name, config); err != nil {
+		return "", err
+	}
+	tlsConfigCache.nameMap[key] = name
+	return name, nil
+}
+
+// newTLSConfig returns a tls.Config implementing the semantics of the supplied
+// ssl-mode, which must be one of required, verify-ca, or verify-identity. As
+// with the standard mysql client, supplying a CA with ssl-mode=required causes
+// the server certificate to be verified against the CA, same as verify-ca.
+func newTLSConfig(sslMode, caPath, certPath, keyPath string) (*tls.Config, error) {
+	config := &tls.Config{}
+
+	if certPath != "" || keyPath != "" {
+		if certPath == "" || keyPath == "" {
+			return nil, errors.New("ssl-cert and ssl-key must be supplied together")
+		}
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to load client certificate from ssl-cert %s and ssl-key %s: %s", certPath, keyPath, err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if caPath != "" {
+		pem, err := ioutil.ReadFile(caPath)
+		if err != nil {
+			return nil, fmt.Errorf("Unable to read ssl-ca file: %s", err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("Unable to parse any PEM-encoded certificates from ssl-ca file %s", caPath)
+		}
+	} else if sslMode == "required" {
+		// Encrypt, but don't verify the server certificate at all
+		config.InsecureSkipVerify = true
+		return config, nil
+	}
+
+	// For verify-identity, the driver sets ServerName to each connection's host,
+	// and standard verification (including the hostname) is performed. For
+	// verify-ca (or required with a CA), the certificate chain is verified
+	// manually, without checking the hostname.
+	if sslMode != "verify-identity" {
+		rootCAs := config.RootCAs
+		config.InsecureSkipVerify = true
+		config.VerifyPeerCertificate = func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+			return verifyCertChain(rawCerts, rootCAs)
+		}
+	}
+	return config, nil
+}
+
+// verifyCertChain confirms the supplied raw certificates form a valid chain to
+// one of rootCAs (or the system pool if rootCAs is nil), without verifying the
+// server's hostname.
+func verifyCertChain(rawCerts [][]byte, rootCAs *x509.CertPool) error {
+	if len(rawCerts) == 0 {
+		return errors.New("Server did not present a certificate")
+	}
+	certs := make([]*x509.Certificate, len(rawCerts))
+	for n, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return fmt.Errorf("Unable to parse server certificate: %s", err)
+		}
+		certs[n] = cert
+	}
+	opts := x509.VerifyOptions{
+		Roots:         rootCAs,
+		Intermediates: x509.NewCertPool(),
+	}
+	for _, cert := range certs[1:] {
+		opts.Intermediates.AddCert(cert)
+	}
+	_, err := certs[0].Verify(opts)
+	return err
+}
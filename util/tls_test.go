@@ -0,0 +1,129 @@
+package util
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegisterTLSConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "skeematls")
+	if err != nil {
+		t.Fatalf("Unable to create temp dir: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	certPath, keyPath, certDER := writeSelfSignedCert(t, dir)
+
+	expectValue := map[string]string{
+		"":          "",
+		"disabled":  "",
+		"DISABLED":  "",
+		"preferred": "preferred",
+	}
+	for sslMode, expected := range expectValue {
+		if actual, err := RegisterTLSConfig(sslMode, "", "", ""); err != nil || actual != expected {
+			t.Errorf("Unexpected return from RegisterTLSConfig(%q): %q / %v", sslMode, actual, err)
+		}
+	}
+
+	// Identical requests should return the same registered name; different ones
+	// should not
+	name1, err := RegisterTLSConfig("required", "", "", "")
+	if err != nil || name1 == "" {
+		t.Fatalf("Unexpected return from RegisterTLSConfig: %q / %v", name1, err)
+	}
+	name2, err := RegisterTLSConfig("REQUIRED", "", "", "")
+	if err != nil || name2 != name1 {
+		t.Errorf("Expected identical requests to return %q, instead found %q / %v", name1, name2, err)
+	}
+	name3, err := RegisterTLSConfig("verify-identity", certPath, certPath, keyPath)
+	if err != nil || name3 == "" || name3 == name1 {
+		t.Errorf("Unexpected return from RegisterTLSConfig: %q / %v", name3, err)
+	}
+
+	expectErrors := [][4]string{
+		{"sometimes", "", "", ""},
+		{"preferred", certPath, "", ""},
+		{"verify-ca", filepath.Join(dir, "nonexistent.pem"), "", ""},
+		{"verify-ca", keyPath, "", ""},
+		{"required", "", certPath, ""},
+		{"required", "", certPath, filepath.Join(dir, "nonexistent.pem")},
+	}
+	for _, args := range expectErrors {
+		if _, err := RegisterTLSConfig(args[0], args[1], args[2], args[3]); err == nil {
+			t.Errorf("Expected error from RegisterTLSConfig%q, but err was nil", args)
+		}
+	}
+
+	// Confirm verify-ca semantics: chain verified against the CA, but no hostname
+	// verification
+	config, err := newTLSConfig("verify-ca", certPath, "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error from newTLSConfig: %s", err)
+	}
+	if !config.InsecureSkipVerify || config.VerifyPeerCertificate == nil {
+		t.Fatal("Expected verify-ca to use custom verification, but it did not")
+	}
+	if err := config.VerifyPeerCertificate([][]byte{certDER}, nil); err != nil {
+		t.Errorf("Unexpected error verifying cert signed by CA: %s", err)
+	}
+	otherConfig, err := newTLSConfig("verify-ca", "", "", "")
+	if err != nil {
+		t.Fatalf("Unexpected error from newTLSConfig: %s", err)
+	}
+	if err := otherConfig.VerifyPeerCertificate([][]byte{certDER}, nil); err == nil {
+		t.Error("Expected error verifying self-signed cert against system CAs, but err was nil")
+	}
+	if config, err = newTLSConfig("verify-identity", certPath, "", ""); err != nil {
+		t.Fatalf("Unexpected error from newTLSConfig: %s", err)
+	} else if config.InsecureSkipVerify || config.VerifyPeerCertificate != nil {
+		t.Error("Expected verify-identity to use standard verification, but it did not")
+	}
+}
+
+// writeSelfSignedCert writes a self-signed certificate and its private key to
+// PEM files in dir, returning their paths and the certificate's DER bytes.
+func writeSelfSignedCert(t *testing.T, dir string) (certPath, keyPath string, certDER []byte) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unable to generate key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "skeema test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if certDER, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key); err != nil {
+		t.Fatalf("Unable to create certificate: %s", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("Unable to marshal key: %s", err)
+	}
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	if err := ioutil.WriteFile(certPath, certPEM, 0600); err != nil {
+		t.Fatalf("Unable to write %s: %s", certPath, err)
+	}
+	if err := ioutil.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		t.Fatalf("Unable to write %s: %s", keyPath, err)
+	}
+	return certPath, keyPath, certDER
+}
@@ -3,8 +3,10 @@ package workspace
 import (
 	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/jmoiron/sqlx"
+	log "github.com/sirupsen/logrus"
 	"github.com/skeema/tengo"
 )
 
@@ -18,6 +20,8 @@ type TempSchema struct {
 	skipBinlog  bool
 	inst        *tengo.Instance
 	releaseLock releaseFunc
+	mu          sync.Mutex // protects releaseLock, since shutdown may be called from a signal handler
+	deregister  func()     // de-registers shutdown, once Cleanup has succeeded
 }
 
 // NewTempSchema creates a temporary schema on the supplied instance and returns
@@ -77,6 +81,7 @@ func NewTempSchema(opts Options) (ts *TempSchema, err error) {
 			return ts, fmt.Errorf("Cannot create temporary schema on %s: %s", ts.inst, err)
 		}
 	}
+	ts.deregister = RegisterShutdownFunc(ts.shutdown)
 	return ts, nil
 }
 
@@ -96,6 +101,19 @@ func (ts *TempSchema) IntrospectSchema() (*tengo.Schema, error) {
 // tables have any rows in the temp schema, the cleanup aborts and an error is
 // returned.
 func (ts *TempSchema) Cleanup() error {
+	// ts.mu must be released before de-registering, since Shutdown holds the
+	// registry lock while calling ts.shutdown, which also acquires ts.mu
+	ts.mu.Lock()
+	err := ts.cleanup()
+	ts.mu.Unlock()
+	if err == nil {
+		ts.deregister()
+	}
+	return err
+}
+
+// cleanup performs the work of Cleanup. The caller must hold ts.mu.
+func (ts *TempSchema) cleanup() error {
 	if ts.releaseLock == nil {
 		return errors.New("Cleanup() called multiple times on same TempSchema")
 	}
@@ -121,3 +139,18 @@ func (ts *TempSchema) Cleanup() error {
 	}
 	return nil
 }
+
+// shutdown handles shutdown logic for a specific TempSchema. If the program is
+// exiting before Cleanup was called -- for example, due to receiving a signal
+// -- the temporary schema is cleaned up here. Otherwise this is a no-op. In
+// either case the function is de-registered afterwards.
+func (ts *TempSchema) shutdown(args ...interface{}) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.releaseLock != nil {
+		if err := ts.cleanup(); err != nil {
+			log.Warnf("Unable to clean up temporary schema during shutdown: %s", err)
+		}
+	}
+	return true
+}
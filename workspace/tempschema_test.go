@@ -94,6 +94,21 @@ func (s WorkspaceIntegrationSuite) TestTempSchemaCleanupDrop(t *testing.T) {
 		t.Fatalf("Schema persisted despite CleanupActionDrop: has=%t err=%s", has, err)
 	}
 
+	// Shutdown prior to Cleanup (e.g. due to a signal) should drop the schema;
+	// Cleanup after that should then error since it was already done
+	if ts, err = NewTempSchema(opts); err != nil {
+		t.Fatalf("Unexpected error from NewTempSchema: %s", err)
+	}
+	if deregister := ts.shutdown(); !deregister {
+		t.Error("Expected shutdown to return true, but it returned false")
+	}
+	if has, err := ts.inst.HasSchema(opts.SchemaName); has || err != nil {
+		t.Fatalf("Schema persisted despite Shutdown: has=%t err=%s", has, err)
+	}
+	if err := ts.Cleanup(); err == nil {
+		t.Error("Expected Cleanup after Shutdown to error, but err was nil")
+	}
+
 	// Coverage for failed CleanupActionDrop due to row present
 	if ts, err = NewTempSchema(opts); err != nil {
 		t.Fatalf("Unexpected error from NewTempSchema: %s", err)
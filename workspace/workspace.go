@@ -9,6 +9,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -135,9 +136,19 @@ func OptionsForDir(dir *fs.Dir, instance *tengo.Instance) (Options, error) {
 		} else if cleanup == "destroy" {
 			opts.CleanupAction = CleanupActionDestroy
 		}
-		if opts.DefaultConnParams, err = dir.InstanceDefaultParams(); err != nil {
+		params, err := dir.InstanceDefaultParams()
+		if err != nil {
 			return Options{}, err
 		}
+		// The tls param reflects ssl-mode for connecting to real database servers.
+		// Containers have their own self-signed certs, which won't match the
+		// configured CA or hostname, so TLS is never used with them.
+		values, err := url.ParseQuery(params)
+		if err != nil {
+			return Options{}, err
+		}
+		values.Del("tls")
+		opts.DefaultConnParams = values.Encode()
 	} else {
 		opts.Type = TypeTempSchema
 		opts.Instance = instance
@@ -171,7 +182,13 @@ func OptionsForDir(dir *fs.Dir, instance *tengo.Instance) (Options, error) {
 // false otherwise.
 type ShutdownFunc func(...interface{}) bool
 
-var shutdownFuncs []ShutdownFunc
+// shutdownEntry wraps a registered ShutdownFunc, so that it can be identified
+// for de-registration, since funcs are not comparable.
+type shutdownEntry struct {
+	f ShutdownFunc
+}
+
+var shutdownFuncs []*shutdownEntry
 var shutdownFuncMutex sync.Mutex
 
 // Shutdown performs any necessary cleanup operations prior to the program
@@ -183,10 +200,10 @@ var shutdownFuncMutex sync.Mutex
 func Shutdown(args ...interface{}) {
 	shutdownFuncMutex.Lock()
 	defer shutdownFuncMutex.Unlock()
-	retainedFuncs := make([]ShutdownFunc, 0, len(shutdownFuncs))
-	for _, f := range shutdownFuncs {
-		if deregister := f(args...); !deregister {
-			retainedFuncs = append(retainedFuncs, f)
+	retainedFuncs := make([]*shutdownEntry, 0, len(shutdownFuncs))
+	for _, entry := range shutdownFuncs {
+		if deregister := entry.f(args...); !deregister {
+			retainedFuncs = append(retainedFuncs, entry)
 		}
 	}
 	shutdownFuncs = retainedFuncs
@@ -195,11 +212,24 @@ func Shutdown(args ...interface{}) {
 // RegisterShutdownFunc registers a function to be executed by Shutdown.
 // Structs satisfying the Workspace interface may optionally use this function
 // to track actions to perform at shutdown time, such as stopping or destroying
-// containers.
-func RegisterShutdownFunc(f ShutdownFunc) {
+// containers. The returned function de-registers f, for use if f is no longer
+// needed prior to Shutdown being called. It is safe to call even if f has
+// already been de-registered by Shutdown.
+func RegisterShutdownFunc(f ShutdownFunc) (deregister func()) {
 	shutdownFuncMutex.Lock()
 	defer shutdownFuncMutex.Unlock()
-	shutdownFuncs = append(shutdownFuncs, f)
+	entry := &shutdownEntry{f: f}
+	shutdownFuncs = append(shutdownFuncs, entry)
+	return func() {
+		shutdownFuncMutex.Lock()
+		defer shutdownFuncMutex.Unlock()
+		for i, other := range shutdownFuncs {
+			if other == entry {
+				shutdownFuncs = append(shutdownFuncs[:i], shutdownFuncs[i+1:]...)
+				return
+			}
+		}
+	}
 }
 
 // StatementError represents a problem that occurred when executing a specific
@@ -36,6 +36,67 @@ func TestIntegration(t *testing.T) {
 	tengo.RunSuite(suite, t, images)
 }
 
+func TestShutdown(t *testing.T) {
+	// Funcs only act on a matching arg, so that any other registered funcs are
+	// unaffected by this test, and vice versa
+	const arg = "test-shutdown"
+	var onceCalls, retainedCalls, deregisteredCalls int
+	RegisterShutdownFunc(func(args ...interface{}) bool {
+		if len(args) == 0 || args[0] != arg {
+			return false
+		}
+		onceCalls++
+		return true
+	})
+	RegisterShutdownFunc(func(args ...interface{}) bool {
+		if len(args) > 0 && args[0] == arg {
+			retainedCalls++
+		}
+		return false
+	})
+	deregister := RegisterShutdownFunc(func(args ...interface{}) bool {
+		if len(args) > 0 && args[0] == arg {
+			deregisteredCalls++
+		}
+		return false
+	})
+	deregister()
+	deregister() // should be a no-op
+
+	Shutdown("no-match")
+	Shutdown(arg)
+	Shutdown(arg)
+	if onceCalls != 1 || retainedCalls != 2 || deregisteredCalls != 0 {
+		t.Errorf("Unexpected call counts: once=%d, retained=%d, deregistered=%d", onceCalls, retainedCalls, deregisteredCalls)
+	}
+}
+
+func TestOptionsForDirDockerTLS(t *testing.T) {
+	cmd := mybase.NewCommand("workspacetest", "", "", nil)
+	util.AddGlobalOptions(cmd)
+	cmd.AddArg("environment", "production", false)
+	cfg := mybase.ParseFakeCLI(t, cmd, "workspacetest --workspace=docker --flavor=mysql:5.7 --ssl-mode=required --connect-options='wait_timeout=123'")
+	dir, err := fs.ParseDir("../testdata/golden/init/mydb/product", cfg)
+	if err != nil {
+		t.Fatalf("Unexpectedly cannot parse working dir: %s", err)
+	}
+
+	// The dir's instances should use TLS, but the docker workspace should not
+	if params, err := dir.InstanceDefaultParams(); err != nil || !strings.Contains(params, "tls=") {
+		t.Fatalf("Expected instance params to include tls, instead found %q / %v", params, err)
+	}
+	opts, err := OptionsForDir(dir, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error from OptionsForDir: %s", err)
+	}
+	if strings.Contains(opts.DefaultConnParams, "tls=") {
+		t.Errorf("Expected docker workspace to omit tls param, instead found params %s", opts.DefaultConnParams)
+	}
+	if !strings.Contains(opts.DefaultConnParams, "wait_timeout=123") {
+		t.Errorf("Expected docker workspace to retain other params, instead found params %s", opts.DefaultConnParams)
+	}
+}
+
 type WorkspaceIntegrationSuite struct {
 	manager *tengo.DockerClient
 	d       *tengo.DockerizedInstance
@@ -235,6 +296,11 @@ func (s WorkspaceIntegrationSuite) TestOptionsForDir(t *testing.T) {
 	if opts = getOpts("--workspace=docker --flavor=mysql:5.5"); opts.Flavor.String() != "mysql:5.5" {
 		t.Errorf("Unexpected return from OptionsForDir: %+v", opts)
 	}
+
+	// ssl-mode should apply to real instances, but never to docker containers
+	if opts = getOpts("--workspace=docker --ssl-mode=required"); strings.Contains(opts.DefaultConnParams, "tls=") {
+		t.Errorf("Expected docker workspace to omit tls param, instead found params %s", opts.DefaultConnParams)
+	}
 }
 
 // TestPrefab confirms that ExecLogicalSchema still functions properly with a